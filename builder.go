@@ -0,0 +1,109 @@
+package ethrlp
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ListBuilder is a fluent builder for (nested) list values.
+// Nested lists are started with List, and finished with End,
+// which returns the builder of the parent list:
+//
+//	value := NewListBuilder().
+//		Uint(9).
+//		String("dog").
+//		List().
+//			String("cat").
+//		End().
+//		Build()
+type ListBuilder struct {
+	parent *ListBuilder
+	values []Value
+}
+
+// NewListBuilder creates a new (top-level) list builder
+func NewListBuilder() *ListBuilder {
+	return &ListBuilder{
+		values: make([]Value, 0),
+	}
+}
+
+// Value adds the given value as a list element
+func (b *ListBuilder) Value(value Value) *ListBuilder {
+	b.values = append(b.values, value)
+
+	return b
+}
+
+// Bytes adds the given byte array as a list element
+func (b *ListBuilder) Bytes(value []byte) *ListBuilder {
+	return b.Value(NewBytes(value))
+}
+
+// String adds the given string as a list element
+func (b *ListBuilder) String(value string) *ListBuilder {
+	return b.Value(NewString(value))
+}
+
+// Uint adds the given unsigned integer as a list element
+func (b *ListBuilder) Uint(value uint64) *ListBuilder {
+	return b.Value(NewUint(value))
+}
+
+// BigInt adds the given big integer as a list element.
+// BigInt panics if the input is negative or nil
+func (b *ListBuilder) BigInt(value *big.Int) *ListBuilder {
+	return b.Value(NewBigInt(value))
+}
+
+// Bool adds the given boolean as a list element
+func (b *ListBuilder) Bool(value bool) *ListBuilder {
+	return b.Value(NewBool(value))
+}
+
+// Encoder adds the RLP encoding of the given encoder as a list element.
+// The encoding is kept as-is (as a RawValue).
+// Encoder panics if the encoder produces an invalid RLP item
+func (b *ListBuilder) Encoder(e RLPEncoder) *ListBuilder {
+	raw, err := DecodeRaw(e.AppendRLP(nil))
+	if err != nil {
+		panic(fmt.Sprintf("ethrlp: invalid encoder output, %v", err))
+	}
+
+	return b.Value(raw)
+}
+
+// List starts a nested list, and returns its builder.
+// The nested list is added as an element once End is called
+func (b *ListBuilder) List() *ListBuilder {
+	return &ListBuilder{
+		parent: b,
+		values: make([]Value, 0),
+	}
+}
+
+// End finishes the nested list, adds it to the parent list,
+// and returns the builder of the parent list.
+// End panics if called on the top-level list builder
+func (b *ListBuilder) End() *ListBuilder {
+	if b.parent == nil {
+		panic("ethrlp: End called on a top-level list builder")
+	}
+
+	return b.parent.Value(ListValue{values: b.values})
+}
+
+// Build returns the built list value.
+// Build panics if called on a nested (unfinished) list builder
+func (b *ListBuilder) Build() ListValue {
+	if b.parent != nil {
+		panic("ethrlp: Build called on an unfinished nested list builder")
+	}
+
+	return ListValue{values: b.values}
+}
+
+// Encode returns the RLP encoding of the built list value
+func (b *ListBuilder) Encode() []byte {
+	return EncodeValue(b.Build())
+}
@@ -0,0 +1,158 @@
+package ethrlp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValue_Constructors(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		value          Value
+		name           string
+		expectedOutput []byte
+	}{
+		{
+			NewBytes([]byte{0x80}),
+			"bytes",
+			EncodeBytes([]byte{0x80}),
+		},
+		{
+			NewString("dog"),
+			"string",
+			EncodeString("dog"),
+		},
+		{
+			NewUint(0),
+			"uint 0",
+			EncodeUint(0),
+		},
+		{
+			NewUint(1024),
+			"uint 1024",
+			EncodeUint(1024),
+		},
+		{
+			NewBigInt(big.NewInt(0).Lsh(big.NewInt(1), 100)),
+			"big int",
+			EncodeBigInt(big.NewInt(0).Lsh(big.NewInt(1), 100)),
+		},
+		{
+			NewBool(true),
+			"bool true",
+			EncodeBool(true),
+		},
+		{
+			NewBool(false),
+			"bool false",
+			EncodeBool(false),
+		},
+		{
+			NewList(),
+			"empty list",
+			EmptyArray,
+		},
+		{
+			NewList(NewString("cat"), NewList(NewString("dog"))),
+			"nested list",
+			EncodeArray([][]byte{
+				EncodeString("cat"),
+				EncodeArray([][]byte{
+					EncodeString("dog"),
+				}),
+			}),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded := EncodeValue(testCase.value)
+			assert.Equal(t, testCase.expectedOutput, encoded)
+
+			// Make sure the constructed value
+			// matches the decoded value
+			decoded, err := DecodeStrict(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, decoded, testCase.value)
+		})
+	}
+}
+
+func TestListBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flat list", func(t *testing.T) {
+		t.Parallel()
+
+		encoded := NewListBuilder().
+			String("cat").
+			String("dog").
+			Encode()
+
+		assert.Equal(t, hexToBytes(t, "c88363617483646f67"), encoded)
+	})
+
+	t.Run("nested lists", func(t *testing.T) {
+		t.Parallel()
+
+		// [ [], [[]], [ [], [[]] ] ]
+		value := NewListBuilder().
+			List().End().
+			List().
+			List().End().
+			End().
+			List().
+			List().End().
+			List().
+			List().End().
+			End().
+			End().
+			Build()
+
+		assert.Equal(t, hexToBytes(t, "c7c0c1c0c3c0c1c0"), EncodeValue(value))
+	})
+
+	t.Run("EIP155 transaction", func(t *testing.T) {
+		t.Parallel()
+
+		r, _ := big.NewInt(0).SetString("28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276", 16)
+		s, _ := big.NewInt(0).SetString("67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", 16)
+
+		encoded := NewListBuilder().
+			Uint(9).
+			Uint(20000000000).
+			Uint(21000).
+			Bytes(hexToBytes(t, "3535353535353535353535353535353535353535")).
+			Uint(1000000000000000000).
+			Bytes([]byte{}).
+			Uint(37).
+			BigInt(r).
+			BigInt(s).
+			Encode()
+
+		assert.Equal(
+			t,
+			hexToBytes(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+			encoded,
+		)
+	})
+
+	t.Run("mismatched list ends", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			NewListBuilder().End()
+		})
+
+		assert.Panics(t, func() {
+			NewListBuilder().List().Build()
+		})
+	})
+}
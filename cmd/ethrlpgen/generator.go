@@ -0,0 +1,585 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const ethrlpPath = "github.com/sig-0/ethrlp"
+
+var (
+	errTypeNotFound    = errors.New("type not found")
+	errNotStruct       = errors.New("type is not a struct")
+	errUnsupportedType = errors.New("unsupported field type")
+)
+
+// fieldKind is the encoding category of a struct field type
+type fieldKind int
+
+const (
+	kindBool fieldKind = iota
+	kindUint
+	kindString
+	kindBytes
+	kindByteArray
+	kindBigInt
+	kindEncoder
+	kindSlice
+	kindArray
+)
+
+// loadPackage parses and type-checks the Go package located at dir,
+// skipping test files and the (previously generated) output file
+func loadPackage(dir, exclude string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load package, %w", err)
+	}
+
+	var (
+		fset  = token.NewFileSet()
+		files = make([]*ast.File, 0, len(buildPkg.GoFiles))
+	)
+
+	for _, name := range buildPkg.GoFiles {
+		if name == exclude {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse file, %w", err)
+		}
+
+		files = append(files, file)
+	}
+
+	config := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// The package can reference methods that are not yet generated,
+		// so type errors are tolerated (unresolved field types are reported later)
+		Error: func(error) {},
+	}
+
+	//nolint:errcheck // Type errors are tolerated
+	pkg, _ := config.Check(buildPkg.ImportPath, fset, files, nil)
+
+	return pkg, nil
+}
+
+// generate generates the formatted source code of the RLP methods
+// for the given struct types, declared in the given package
+func generate(pkg *types.Package, typeNames []string) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		imports: make(map[string]string),
+	}
+
+	for _, name := range typeNames {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errTypeNotFound, name)
+		}
+
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errNotStruct, name)
+		}
+
+		fields, err := encodedFields(st)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		g.generateType(name, fields)
+	}
+
+	var out bytes.Buffer
+
+	fmt.Fprintf(&out, "// Code generated by ethrlpgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg.Name())
+	fmt.Fprintf(&out, "import (\n")
+
+	// Standard library imports are grouped separately
+	var stdPaths, otherPaths []string
+
+	for importPath := range g.imports {
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			otherPaths = append(otherPaths, importPath)
+
+			continue
+		}
+
+		stdPaths = append(stdPaths, importPath)
+	}
+
+	sort.Strings(stdPaths)
+	sort.Strings(otherPaths)
+
+	for index, paths := range [][]string{stdPaths, otherPaths} {
+		if index > 0 && len(paths) > 0 {
+			fmt.Fprintf(&out, "\n")
+		}
+
+		for _, importPath := range paths {
+			if name := g.imports[importPath]; name != path.Base(importPath) {
+				fmt.Fprintf(&out, "\t%s %q\n", name, importPath)
+
+				continue
+			}
+
+			fmt.Fprintf(&out, "\t%q\n", importPath)
+		}
+	}
+
+	fmt.Fprintf(&out, ")\n\n")
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format generated code, %w", err)
+	}
+
+	return src, nil
+}
+
+// encodedFields returns the struct fields that are part of the encoding
+// (exported, and not tagged with `rlp:"-"`), making sure their types are supported
+func encodedFields(st *types.Struct) ([]*types.Var, error) {
+	fields := make([]*types.Var, 0, st.NumFields())
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+
+		if !field.Exported() || reflect.StructTag(st.Tag(i)).Get("rlp") == "-" {
+			continue
+		}
+
+		if err := checkType(field.Type()); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name(), err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// checkType makes sure the given type (and any nested type) is supported
+func checkType(t types.Type) error {
+	kind, err := classify(t)
+	if err != nil {
+		return err
+	}
+
+	if kind == kindSlice || kind == kindArray {
+		return checkType(elemType(t))
+	}
+
+	return nil
+}
+
+// elemType returns the element type of the given slice or array type
+func elemType(t types.Type) types.Type {
+	//nolint:forcetypeassert // Only called for slices and arrays
+	return types.Unalias(t).Underlying().(interface{ Elem() types.Type }).Elem()
+}
+
+// classify returns the encoding category of the given type
+func classify(t types.Type) (fieldKind, error) {
+	t = types.Unalias(t)
+
+	if isBigIntPtr(t) {
+		return kindBigInt, nil
+	}
+
+	// Types with their own RLP methods take precedence over the default encoding
+	if hasRLPMethods(t) {
+		return kindEncoder, nil
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch u.Kind() {
+		case types.Bool:
+			return kindBool, nil
+		case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			return kindUint, nil
+		case types.String:
+			return kindString, nil
+		}
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return kindBytes, nil
+		}
+
+		return kindSlice, nil
+	case *types.Array:
+		if isByte(u.Elem()) {
+			return kindByteArray, nil
+		}
+
+		return kindArray, nil
+	case *types.Struct:
+		// The methods of struct types are expected to be generated
+		// (in the same run), or hand-written
+		if _, ok := t.(*types.Named); ok {
+			return kindEncoder, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", errUnsupportedType, types.TypeString(t, packageName))
+}
+
+// packageName qualifies type names with the (short) name of their package
+func packageName(p *types.Package) string {
+	return p.Name()
+}
+
+// isBigIntPtr checks if the given type is *big.Int
+func isBigIntPtr(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == "math/big" && obj.Name() == "Int"
+}
+
+// hasRLPMethods checks if the given type is a named type whose pointer
+// implements both ethrlp.RLPEncoder and ethrlp.RLPDecoder
+func hasRLPMethods(t types.Type) bool {
+	if _, ok := t.(*types.Named); !ok {
+		return false
+	}
+
+	methods := types.NewMethodSet(types.NewPointer(t))
+
+	return methods.Lookup(nil, "AppendRLP") != nil && methods.Lookup(nil, "DecodeRLP") != nil
+}
+
+// isByte checks if the given type is byte (uint8)
+func isByte(t types.Type) bool {
+	return types.Identical(t, types.Typ[types.Byte])
+}
+
+// generator accumulates the generated method source code
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string // import path -> package name
+	buf     bytes.Buffer
+}
+
+// printf writes the formatted source code to the output
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// typeString returns the source representation of the given type,
+// recording the imports it requires
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+
+		g.imports[p.Path()] = p.Name()
+
+		return p.Name()
+	})
+}
+
+// convert returns the expression converted to the given type,
+// if the type is a named type (otherwise, no conversion is required)
+func (g *generator) convert(expr string, t types.Type) string {
+	if _, ok := types.Unalias(t).(*types.Named); !ok {
+		return expr
+	}
+
+	return fmt.Sprintf("%s(%s)", g.typeString(t), expr)
+}
+
+// convertTo returns the expression (of the given type) converted to the given basic type,
+// if the type is a named type. Named bool and string types are not assignable
+// to their basic types, as the basic types are named as well
+func convertTo(expr string, t types.Type, basic string) string {
+	if _, ok := types.Unalias(t).(*types.Named); !ok {
+		return expr
+	}
+
+	return fmt.Sprintf("%s(%s)", basic, expr)
+}
+
+// generateType generates the RLP methods for the given struct type
+func (g *generator) generateType(name string, fields []*types.Var) {
+	g.imports[ethrlpPath] = "ethrlp"
+	g.imports["fmt"] = "fmt"
+
+	// EncodeRLP
+	g.printf("// EncodeRLP returns the RLP encoding of the %s\n", name)
+	g.printf("func (x *%s) EncodeRLP() []byte {\n", name)
+	g.printf("return x.AppendRLP(nil)\n")
+	g.printf("}\n\n")
+
+	// AppendRLP
+	g.printf("// AppendRLP appends the RLP encoding of the %s to dst,\n", name)
+	g.printf("// and returns the extended byte array\n")
+	g.printf("func (x *%s) AppendRLP(dst []byte) []byte {\n", name)
+	g.printf("return ethrlp.AppendList(dst, func(dst []byte) []byte {\n")
+
+	for _, field := range fields {
+		g.generateAppend("x."+field.Name(), field.Type(), 0)
+	}
+
+	g.printf("\nreturn dst\n")
+	g.printf("})\n")
+	g.printf("}\n\n")
+
+	// DecodeRLP
+	g.printf("// DecodeRLP decodes the given RLP value into the %s\n", name)
+	g.printf("func (x *%s) DecodeRLP(v ethrlp.Value) error {\n", name)
+	g.printf("list, err := ethrlp.AsList(v)\n")
+	g.printf("if err != nil {\n")
+	g.printf("return fmt.Errorf(\"%s: %%w\", err)\n", name)
+	g.printf("}\n\n")
+	g.printf("if list.Len() != %d {\n", len(fields))
+	g.printf(
+		"return fmt.Errorf(\"%s: %%w: expected %d list elements, got %%d\", ethrlp.ErrInvalidLength, list.Len())\n",
+		name,
+		len(fields),
+	)
+	g.printf("}\n\n")
+
+	if len(fields) > 0 {
+		g.printf("values, _ := list.GetValue().([]ethrlp.Value)\n\n")
+	}
+
+	for index, field := range fields {
+		// Every field is decoded in its own scope
+		g.printf("// %s\n", field.Name())
+		g.printf("{\n")
+		g.generateDecode(
+			"x."+field.Name(),
+			fmt.Sprintf("values[%d]", index),
+			field.Type(),
+			name+"."+field.Name(),
+			nil,
+			0,
+		)
+		g.printf("}\n\n")
+	}
+
+	g.printf("return nil\n")
+	g.printf("}\n\n")
+}
+
+// generateAppend generates the code that appends the RLP encoding
+// of the given expression (of the given type) to dst
+func (g *generator) generateAppend(expr string, t types.Type, depth int) {
+	//nolint:errcheck // Types are checked beforehand
+	kind, _ := classify(t)
+
+	switch kind {
+	case kindBool:
+		g.printf("dst = ethrlp.AppendBool(dst, %s)\n", convertTo(expr, t, "bool"))
+	case kindUint:
+		if types.Identical(t, types.Typ[types.Uint64]) {
+			g.printf("dst = ethrlp.AppendUint64(dst, %s)\n", expr)
+
+			return
+		}
+
+		g.printf("dst = ethrlp.AppendUint64(dst, uint64(%s))\n", expr)
+	case kindString:
+		g.printf("dst = ethrlp.AppendString(dst, %s)\n", convertTo(expr, t, "string"))
+	case kindBytes:
+		g.printf("dst = ethrlp.AppendBytes(dst, %s)\n", convertTo(expr, t, "[]byte"))
+	case kindByteArray:
+		g.printf("dst = ethrlp.AppendBytes(dst, %s[:])\n", expr)
+	case kindBigInt:
+		g.printf("if %s == nil {\n", expr)
+		g.printf("dst = ethrlp.AppendUint64(dst, 0)\n")
+		g.printf("} else {\n")
+		g.printf("dst = ethrlp.AppendBigInt(dst, %s)\n", expr)
+		g.printf("}\n")
+	case kindEncoder:
+		g.printf("dst = %s.AppendRLP(dst)\n", expr)
+	case kindSlice, kindArray:
+		index := fmt.Sprintf("i%d", depth)
+
+		g.printf("dst = ethrlp.AppendList(dst, func(dst []byte) []byte {\n")
+		g.printf("for %s := range %s {\n", index, expr)
+		g.generateAppend(fmt.Sprintf("%s[%s]", expr, index), elemType(t), depth+1)
+		g.printf("}\n\n")
+		g.printf("return dst\n")
+		g.printf("})\n")
+	}
+}
+
+// generateDecode generates the code that decodes the given ethrlp.Value
+// expression into the target expression (of the given type).
+// Errors are wrapped with the label (a format string), and its arguments
+func (g *generator) generateDecode(
+	target,
+	value string,
+	t types.Type,
+	label string,
+	labelArgs []string,
+	depth int,
+) {
+	//nolint:errcheck // Types are checked beforehand
+	kind, _ := classify(t)
+
+	// returnErr generates the wrapped error return statement
+	returnErr := func(format, errExpr string) {
+		args := append(append([]string{}, labelArgs...), errExpr)
+
+		g.printf("return fmt.Errorf(\"%s: %s\", %s)\n", label, format, strings.Join(args, ", "))
+	}
+
+	var (
+		b = fmt.Sprintf("b%d", depth) // bytes value variable
+		l = fmt.Sprintf("l%d", depth) // list value variable
+		v = fmt.Sprintf("v%d", depth) // decoded value variable
+	)
+
+	switch kind {
+	case kindSlice, kindArray:
+		var (
+			index = fmt.Sprintf("i%d", depth)
+			item  = fmt.Sprintf("e%d", depth)
+		)
+
+		g.printf("%s, err := ethrlp.AsList(%s)\n", l, value)
+		g.printf("if err != nil {\n")
+		returnErr("%w", "err")
+		g.printf("}\n\n")
+
+		if kind == kindSlice {
+			g.printf("%s = make(%s, %s.Len())\n\n", target, g.typeString(t), l)
+		} else {
+			//nolint:forcetypeassert // The array kind is only returned for arrays
+			size := types.Unalias(t).Underlying().(*types.Array).Len()
+
+			g.printf("if %s.Len() != %d {\n", l, size)
+			returnErr(
+				fmt.Sprintf("%%w: expected %d list elements, got %%d", size),
+				fmt.Sprintf("ethrlp.ErrInvalidLength, %s.Len()", l),
+			)
+			g.printf("}\n\n")
+		}
+
+		g.printf("for %s, %s := range %s.All() {\n", index, item, l)
+		g.generateDecode(
+			fmt.Sprintf("%s[%s]", target, index),
+			item,
+			elemType(t),
+			label+"[%d]",
+			append(append([]string{}, labelArgs...), index),
+			depth+1,
+		)
+		g.printf("}\n")
+
+		return
+	case kindEncoder:
+		g.printf("if err := %s.DecodeRLP(%s); err != nil {\n", target, value)
+		returnErr("%w", "err")
+		g.printf("}\n")
+
+		return
+	default:
+	}
+
+	// The remaining kinds are all byte strings
+	g.printf("%s, err := ethrlp.AsBytes(%s)\n", b, value)
+	g.printf("if err != nil {\n")
+	returnErr("%w", "err")
+	g.printf("}\n\n")
+
+	switch kind {
+	case kindBool:
+		g.printf("%s, err := %s.Bool()\n", v, b)
+		g.printf("if err != nil {\n")
+		returnErr("%w", "err")
+		g.printf("}\n\n")
+		g.printf("%s = %s\n", target, g.convert(v, t))
+	case kindUint:
+		g.printf("%s, err := %s.Uint64()\n", v, b)
+		g.printf("if err != nil {\n")
+		returnErr("%w", "err")
+		g.printf("}\n\n")
+
+		if maxValue := uintMax(t); maxValue != "" {
+			g.imports["math"] = "math"
+
+			g.printf("if %s > %s {\n", v, maxValue)
+			returnErr("%w", "ethrlp.ErrUintOverflow")
+			g.printf("}\n\n")
+		}
+
+		if types.Identical(t, types.Typ[types.Uint64]) {
+			g.printf("%s = %s\n", target, v)
+
+			return
+		}
+
+		g.printf("%s = %s(%s)\n", target, g.typeString(t), v)
+	case kindString:
+		g.printf("%s = %s\n", target, g.convert(b+".String()", t))
+	case kindBytes:
+		g.printf("%s = make(%s, len(%s.Bytes()))\n", target, g.typeString(t), b)
+		g.printf("copy(%s, %s.Bytes())\n", target, b)
+	case kindByteArray:
+		//nolint:forcetypeassert // The byte array kind is only returned for arrays
+		size := types.Unalias(t).Underlying().(*types.Array).Len()
+
+		g.printf("if len(%s.Bytes()) != %d {\n", b, size)
+		returnErr(
+			fmt.Sprintf("%%w: expected %dB, got %%dB", size),
+			fmt.Sprintf("ethrlp.ErrInvalidLength, len(%s.Bytes())", b),
+		)
+		g.printf("}\n\n")
+		g.printf("copy(%s[:], %s.Bytes())\n", target, b)
+	case kindBigInt:
+		g.printf("if %s, err = %s.BigInt(); err != nil {\n", target, b)
+		returnErr("%w", "err")
+		g.printf("}\n")
+	default:
+	}
+}
+
+// uintMax returns the expression of the maximum value of the given
+// unsigned integer type, if it is smaller than 64 bits
+func uintMax(t types.Type) string {
+	//nolint:forcetypeassert // The uint kind is only returned for basic types
+	switch t.Underlying().(*types.Basic).Kind() {
+	case types.Uint8:
+		return "math.MaxUint8"
+	case types.Uint16:
+		return "math.MaxUint16"
+	case types.Uint32:
+		return "math.MaxUint32"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_ExampleUpToDate(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("internal", "example")
+
+	pkg, err := loadPackage(dir, "block_rlp.go")
+	require.NoError(t, err)
+
+	src, err := generate(pkg, []string{"Block", "Header", "Transaction"})
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join(dir, "block_rlp.go"))
+	require.NoError(t, err)
+
+	// Make sure the committed example matches the generator output
+	assert.Equal(t, string(expected), string(src), "generated example is stale, run go generate")
+}
+
+func TestGenerate_Run(t *testing.T) {
+	t.Parallel()
+
+	output := filepath.Join(t.TempDir(), "out_rlp.go")
+
+	require.NoError(t, run(filepath.Join("internal", "example"), []string{"Header"}, output))
+
+	src, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(src), "func (x *Header) DecodeRLP(v ethrlp.Value) error")
+	assert.NotContains(t, string(src), "func (x *Block)")
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := loadPackage(filepath.Join("testdata", "unsupported"), "")
+	require.NoError(t, err)
+
+	testTable := []struct {
+		expectedErr error
+		name        string
+		typeName    string
+		message     string
+	}{
+		{
+			errUnsupportedType,
+			"map field",
+			"Unsupported",
+			"field Values: unsupported field type: map[string]int",
+		},
+		{
+			errUnsupportedType,
+			"signed integer field",
+			"Signed",
+			"field Value: unsupported field type: int64",
+		},
+		{
+			errUnsupportedType,
+			"struct pointer field",
+			"NestedPointer",
+			"field Inner: unsupported field type: *unsupported.Inner",
+		},
+		{
+			errNotStruct,
+			"not a struct",
+			"NotStruct",
+			"type is not a struct: NotStruct",
+		},
+		{
+			errTypeNotFound,
+			"missing type",
+			"Missing",
+			"type not found: Missing",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := generate(pkg, []string{testCase.typeName})
+
+			require.ErrorIs(t, err, testCase.expectedErr)
+			assert.Contains(t, err.Error(), testCase.message)
+		})
+	}
+}
+
+func TestGenerate_Arrays(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := loadPackage(filepath.Join("internal", "example"), "block_rlp.go")
+	require.NoError(t, err)
+
+	src, err := generate(pkg, []string{"Block"})
+	require.NoError(t, err)
+
+	// Make sure fixed-size arrays are encoded as lists, with a length check on decode
+	assert.Contains(t, string(src), "for i0 := range x.Pair {")
+	assert.Contains(t, string(src), "if l0.Len() != 2 {")
+	assert.NotContains(t, string(src), "x.Pair = make(")
+}
@@ -0,0 +1,42 @@
+package example
+
+import (
+	"fmt"
+
+	"github.com/sig-0/ethrlp"
+)
+
+// Bits is a bit field with hand-written RLP methods,
+// encoded as a byte string (8 bits per byte, most significant bit first).
+// The number of bits is not kept, so it is rounded up to a multiple of 8
+type Bits []bool
+
+// AppendRLP appends the RLP encoding of the bit field to dst,
+// and returns the extended byte array
+func (b *Bits) AppendRLP(dst []byte) []byte {
+	packed := make([]byte, (len(*b)+7)/8)
+
+	for index, bit := range *b {
+		if bit {
+			packed[index/8] |= 0x80 >> (index % 8)
+		}
+	}
+
+	return ethrlp.AppendBytes(dst, packed)
+}
+
+// DecodeRLP decodes the given RLP value into the bit field
+func (b *Bits) DecodeRLP(v ethrlp.Value) error {
+	packed, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return fmt.Errorf("Bits: %w", err)
+	}
+
+	*b = make(Bits, 8*len(packed.Bytes()))
+
+	for index := range *b {
+		(*b)[index] = packed.Bytes()[index/8]&(0x80>>(index%8)) != 0
+	}
+
+	return nil
+}
@@ -0,0 +1,662 @@
+// Code generated by ethrlpgen. DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/sig-0/ethrlp"
+)
+
+// EncodeRLP returns the RLP encoding of the Block
+func (x *Block) EncodeRLP() []byte {
+	return x.AppendRLP(nil)
+}
+
+// AppendRLP appends the RLP encoding of the Block to dst,
+// and returns the extended byte array
+func (x *Block) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Transactions {
+				dst = x.Transactions[i0].AppendRLP(dst)
+			}
+
+			return dst
+		})
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Uncles {
+				dst = ethrlp.AppendBytes(dst, x.Uncles[i0][:])
+			}
+
+			return dst
+		})
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Matrix {
+				dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+					for i1 := range x.Matrix[i0] {
+						dst = ethrlp.AppendUint64(dst, uint64(x.Matrix[i0][i1]))
+					}
+
+					return dst
+				})
+			}
+
+			return dst
+		})
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Tags {
+				dst = ethrlp.AppendString(dst, x.Tags[i0])
+			}
+
+			return dst
+		})
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Amounts {
+				if x.Amounts[i0] == nil {
+					dst = ethrlp.AppendUint64(dst, 0)
+				} else {
+					dst = ethrlp.AppendBigInt(dst, x.Amounts[i0])
+				}
+			}
+
+			return dst
+		})
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Switches {
+				dst = ethrlp.AppendBool(dst, bool(x.Switches[i0]))
+			}
+
+			return dst
+		})
+		dst = x.Header.AppendRLP(dst)
+		dst = x.Votes.AppendRLP(dst)
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.History {
+				dst = x.History[i0].AppendRLP(dst)
+			}
+
+			return dst
+		})
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Roots {
+				dst = ethrlp.AppendBytes(dst, x.Roots[i0][:])
+			}
+
+			return dst
+		})
+		dst = ethrlp.AppendList(dst, func(dst []byte) []byte {
+			for i0 := range x.Pair {
+				dst = ethrlp.AppendUint64(dst, x.Pair[i0])
+			}
+
+			return dst
+		})
+
+		return dst
+	})
+}
+
+// DecodeRLP decodes the given RLP value into the Block
+func (x *Block) DecodeRLP(v ethrlp.Value) error {
+	list, err := ethrlp.AsList(v)
+	if err != nil {
+		return fmt.Errorf("Block: %w", err)
+	}
+
+	if list.Len() != 11 {
+		return fmt.Errorf("Block: %w: expected 11 list elements, got %d", ethrlp.ErrInvalidLength, list.Len())
+	}
+
+	values, _ := list.GetValue().([]ethrlp.Value)
+
+	// Transactions
+	{
+		l0, err := ethrlp.AsList(values[0])
+		if err != nil {
+			return fmt.Errorf("Block.Transactions: %w", err)
+		}
+
+		x.Transactions = make([]Transaction, l0.Len())
+
+		for i0, e0 := range l0.All() {
+			if err := x.Transactions[i0].DecodeRLP(e0); err != nil {
+				return fmt.Errorf("Block.Transactions[%d]: %w", i0, err)
+			}
+		}
+	}
+
+	// Uncles
+	{
+		l0, err := ethrlp.AsList(values[1])
+		if err != nil {
+			return fmt.Errorf("Block.Uncles: %w", err)
+		}
+
+		x.Uncles = make([][32]byte, l0.Len())
+
+		for i0, e0 := range l0.All() {
+			b1, err := ethrlp.AsBytes(e0)
+			if err != nil {
+				return fmt.Errorf("Block.Uncles[%d]: %w", i0, err)
+			}
+
+			if len(b1.Bytes()) != 32 {
+				return fmt.Errorf("Block.Uncles[%d]: %w: expected 32B, got %dB", i0, ethrlp.ErrInvalidLength, len(b1.Bytes()))
+			}
+
+			copy(x.Uncles[i0][:], b1.Bytes())
+		}
+	}
+
+	// Matrix
+	{
+		l0, err := ethrlp.AsList(values[2])
+		if err != nil {
+			return fmt.Errorf("Block.Matrix: %w", err)
+		}
+
+		x.Matrix = make([][]uint16, l0.Len())
+
+		for i0, e0 := range l0.All() {
+			l1, err := ethrlp.AsList(e0)
+			if err != nil {
+				return fmt.Errorf("Block.Matrix[%d]: %w", i0, err)
+			}
+
+			x.Matrix[i0] = make([]uint16, l1.Len())
+
+			for i1, e1 := range l1.All() {
+				b2, err := ethrlp.AsBytes(e1)
+				if err != nil {
+					return fmt.Errorf("Block.Matrix[%d][%d]: %w", i0, i1, err)
+				}
+
+				v2, err := b2.Uint64()
+				if err != nil {
+					return fmt.Errorf("Block.Matrix[%d][%d]: %w", i0, i1, err)
+				}
+
+				if v2 > math.MaxUint16 {
+					return fmt.Errorf("Block.Matrix[%d][%d]: %w", i0, i1, ethrlp.ErrUintOverflow)
+				}
+
+				x.Matrix[i0][i1] = uint16(v2)
+			}
+		}
+	}
+
+	// Tags
+	{
+		l0, err := ethrlp.AsList(values[3])
+		if err != nil {
+			return fmt.Errorf("Block.Tags: %w", err)
+		}
+
+		x.Tags = make([]string, l0.Len())
+
+		for i0, e0 := range l0.All() {
+			b1, err := ethrlp.AsBytes(e0)
+			if err != nil {
+				return fmt.Errorf("Block.Tags[%d]: %w", i0, err)
+			}
+
+			x.Tags[i0] = b1.String()
+		}
+	}
+
+	// Amounts
+	{
+		l0, err := ethrlp.AsList(values[4])
+		if err != nil {
+			return fmt.Errorf("Block.Amounts: %w", err)
+		}
+
+		x.Amounts = make([]*big.Int, l0.Len())
+
+		for i0, e0 := range l0.All() {
+			b1, err := ethrlp.AsBytes(e0)
+			if err != nil {
+				return fmt.Errorf("Block.Amounts[%d]: %w", i0, err)
+			}
+
+			if x.Amounts[i0], err = b1.BigInt(); err != nil {
+				return fmt.Errorf("Block.Amounts[%d]: %w", i0, err)
+			}
+		}
+	}
+
+	// Switches
+	{
+		l0, err := ethrlp.AsList(values[5])
+		if err != nil {
+			return fmt.Errorf("Block.Switches: %w", err)
+		}
+
+		x.Switches = make([]Toggle, l0.Len())
+
+		for i0, e0 := range l0.All() {
+			b1, err := ethrlp.AsBytes(e0)
+			if err != nil {
+				return fmt.Errorf("Block.Switches[%d]: %w", i0, err)
+			}
+
+			v1, err := b1.Bool()
+			if err != nil {
+				return fmt.Errorf("Block.Switches[%d]: %w", i0, err)
+			}
+
+			x.Switches[i0] = Toggle(v1)
+		}
+	}
+
+	// Header
+	{
+		if err := x.Header.DecodeRLP(values[6]); err != nil {
+			return fmt.Errorf("Block.Header: %w", err)
+		}
+	}
+
+	// Votes
+	{
+		if err := x.Votes.DecodeRLP(values[7]); err != nil {
+			return fmt.Errorf("Block.Votes: %w", err)
+		}
+	}
+
+	// History
+	{
+		l0, err := ethrlp.AsList(values[8])
+		if err != nil {
+			return fmt.Errorf("Block.History: %w", err)
+		}
+
+		x.History = make([]Bits, l0.Len())
+
+		for i0, e0 := range l0.All() {
+			if err := x.History[i0].DecodeRLP(e0); err != nil {
+				return fmt.Errorf("Block.History[%d]: %w", i0, err)
+			}
+		}
+	}
+
+	// Roots
+	{
+		l0, err := ethrlp.AsList(values[9])
+		if err != nil {
+			return fmt.Errorf("Block.Roots: %w", err)
+		}
+
+		if l0.Len() != 2 {
+			return fmt.Errorf("Block.Roots: %w: expected 2 list elements, got %d", ethrlp.ErrInvalidLength, l0.Len())
+		}
+
+		for i0, e0 := range l0.All() {
+			b1, err := ethrlp.AsBytes(e0)
+			if err != nil {
+				return fmt.Errorf("Block.Roots[%d]: %w", i0, err)
+			}
+
+			if len(b1.Bytes()) != 32 {
+				return fmt.Errorf("Block.Roots[%d]: %w: expected 32B, got %dB", i0, ethrlp.ErrInvalidLength, len(b1.Bytes()))
+			}
+
+			copy(x.Roots[i0][:], b1.Bytes())
+		}
+	}
+
+	// Pair
+	{
+		l0, err := ethrlp.AsList(values[10])
+		if err != nil {
+			return fmt.Errorf("Block.Pair: %w", err)
+		}
+
+		if l0.Len() != 2 {
+			return fmt.Errorf("Block.Pair: %w: expected 2 list elements, got %d", ethrlp.ErrInvalidLength, l0.Len())
+		}
+
+		for i0, e0 := range l0.All() {
+			b1, err := ethrlp.AsBytes(e0)
+			if err != nil {
+				return fmt.Errorf("Block.Pair[%d]: %w", i0, err)
+			}
+
+			v1, err := b1.Uint64()
+			if err != nil {
+				return fmt.Errorf("Block.Pair[%d]: %w", i0, err)
+			}
+
+			x.Pair[i0] = v1
+		}
+	}
+
+	return nil
+}
+
+// EncodeRLP returns the RLP encoding of the Header
+func (x *Header) EncodeRLP() []byte {
+	return x.AppendRLP(nil)
+}
+
+// AppendRLP appends the RLP encoding of the Header to dst,
+// and returns the extended byte array
+func (x *Header) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		if x.Number == nil {
+			dst = ethrlp.AppendUint64(dst, 0)
+		} else {
+			dst = ethrlp.AppendBigInt(dst, x.Number)
+		}
+		dst = ethrlp.AppendString(dst, x.Name)
+		dst = ethrlp.AppendString(dst, string(x.Label))
+		dst = ethrlp.AppendBytes(dst, x.Extra)
+		dst = ethrlp.AppendUint64(dst, uint64(x.GasLimit))
+		dst = ethrlp.AppendUint64(dst, x.Timestamp)
+		dst = ethrlp.AppendUint64(dst, uint64(x.Nonce))
+		dst = ethrlp.AppendUint64(dst, uint64(x.Version))
+		dst = ethrlp.AppendUint64(dst, uint64(x.Flags))
+		dst = ethrlp.AppendBytes(dst, x.ParentHash[:])
+		dst = ethrlp.AppendBytes(dst, x.Coinbase[:])
+		dst = ethrlp.AppendBool(dst, x.Final)
+		dst = ethrlp.AppendBool(dst, bool(x.Sealed))
+
+		return dst
+	})
+}
+
+// DecodeRLP decodes the given RLP value into the Header
+func (x *Header) DecodeRLP(v ethrlp.Value) error {
+	list, err := ethrlp.AsList(v)
+	if err != nil {
+		return fmt.Errorf("Header: %w", err)
+	}
+
+	if list.Len() != 13 {
+		return fmt.Errorf("Header: %w: expected 13 list elements, got %d", ethrlp.ErrInvalidLength, list.Len())
+	}
+
+	values, _ := list.GetValue().([]ethrlp.Value)
+
+	// Number
+	{
+		b0, err := ethrlp.AsBytes(values[0])
+		if err != nil {
+			return fmt.Errorf("Header.Number: %w", err)
+		}
+
+		if x.Number, err = b0.BigInt(); err != nil {
+			return fmt.Errorf("Header.Number: %w", err)
+		}
+	}
+
+	// Name
+	{
+		b0, err := ethrlp.AsBytes(values[1])
+		if err != nil {
+			return fmt.Errorf("Header.Name: %w", err)
+		}
+
+		x.Name = b0.String()
+	}
+
+	// Label
+	{
+		b0, err := ethrlp.AsBytes(values[2])
+		if err != nil {
+			return fmt.Errorf("Header.Label: %w", err)
+		}
+
+		x.Label = Label(b0.String())
+	}
+
+	// Extra
+	{
+		b0, err := ethrlp.AsBytes(values[3])
+		if err != nil {
+			return fmt.Errorf("Header.Extra: %w", err)
+		}
+
+		x.Extra = make([]byte, len(b0.Bytes()))
+		copy(x.Extra, b0.Bytes())
+	}
+
+	// GasLimit
+	{
+		b0, err := ethrlp.AsBytes(values[4])
+		if err != nil {
+			return fmt.Errorf("Header.GasLimit: %w", err)
+		}
+
+		v0, err := b0.Uint64()
+		if err != nil {
+			return fmt.Errorf("Header.GasLimit: %w", err)
+		}
+
+		x.GasLimit = Gas(v0)
+	}
+
+	// Timestamp
+	{
+		b0, err := ethrlp.AsBytes(values[5])
+		if err != nil {
+			return fmt.Errorf("Header.Timestamp: %w", err)
+		}
+
+		v0, err := b0.Uint64()
+		if err != nil {
+			return fmt.Errorf("Header.Timestamp: %w", err)
+		}
+
+		x.Timestamp = v0
+	}
+
+	// Nonce
+	{
+		b0, err := ethrlp.AsBytes(values[6])
+		if err != nil {
+			return fmt.Errorf("Header.Nonce: %w", err)
+		}
+
+		v0, err := b0.Uint64()
+		if err != nil {
+			return fmt.Errorf("Header.Nonce: %w", err)
+		}
+
+		if v0 > math.MaxUint32 {
+			return fmt.Errorf("Header.Nonce: %w", ethrlp.ErrUintOverflow)
+		}
+
+		x.Nonce = uint32(v0)
+	}
+
+	// Version
+	{
+		b0, err := ethrlp.AsBytes(values[7])
+		if err != nil {
+			return fmt.Errorf("Header.Version: %w", err)
+		}
+
+		v0, err := b0.Uint64()
+		if err != nil {
+			return fmt.Errorf("Header.Version: %w", err)
+		}
+
+		if v0 > math.MaxUint16 {
+			return fmt.Errorf("Header.Version: %w", ethrlp.ErrUintOverflow)
+		}
+
+		x.Version = uint16(v0)
+	}
+
+	// Flags
+	{
+		b0, err := ethrlp.AsBytes(values[8])
+		if err != nil {
+			return fmt.Errorf("Header.Flags: %w", err)
+		}
+
+		v0, err := b0.Uint64()
+		if err != nil {
+			return fmt.Errorf("Header.Flags: %w", err)
+		}
+
+		if v0 > math.MaxUint8 {
+			return fmt.Errorf("Header.Flags: %w", ethrlp.ErrUintOverflow)
+		}
+
+		x.Flags = uint8(v0)
+	}
+
+	// ParentHash
+	{
+		b0, err := ethrlp.AsBytes(values[9])
+		if err != nil {
+			return fmt.Errorf("Header.ParentHash: %w", err)
+		}
+
+		if len(b0.Bytes()) != 32 {
+			return fmt.Errorf("Header.ParentHash: %w: expected 32B, got %dB", ethrlp.ErrInvalidLength, len(b0.Bytes()))
+		}
+
+		copy(x.ParentHash[:], b0.Bytes())
+	}
+
+	// Coinbase
+	{
+		b0, err := ethrlp.AsBytes(values[10])
+		if err != nil {
+			return fmt.Errorf("Header.Coinbase: %w", err)
+		}
+
+		if len(b0.Bytes()) != 20 {
+			return fmt.Errorf("Header.Coinbase: %w: expected 20B, got %dB", ethrlp.ErrInvalidLength, len(b0.Bytes()))
+		}
+
+		copy(x.Coinbase[:], b0.Bytes())
+	}
+
+	// Final
+	{
+		b0, err := ethrlp.AsBytes(values[11])
+		if err != nil {
+			return fmt.Errorf("Header.Final: %w", err)
+		}
+
+		v0, err := b0.Bool()
+		if err != nil {
+			return fmt.Errorf("Header.Final: %w", err)
+		}
+
+		x.Final = v0
+	}
+
+	// Sealed
+	{
+		b0, err := ethrlp.AsBytes(values[12])
+		if err != nil {
+			return fmt.Errorf("Header.Sealed: %w", err)
+		}
+
+		v0, err := b0.Bool()
+		if err != nil {
+			return fmt.Errorf("Header.Sealed: %w", err)
+		}
+
+		x.Sealed = Toggle(v0)
+	}
+
+	return nil
+}
+
+// EncodeRLP returns the RLP encoding of the Transaction
+func (x *Transaction) EncodeRLP() []byte {
+	return x.AppendRLP(nil)
+}
+
+// AppendRLP appends the RLP encoding of the Transaction to dst,
+// and returns the extended byte array
+func (x *Transaction) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		if x.Value == nil {
+			dst = ethrlp.AppendUint64(dst, 0)
+		} else {
+			dst = ethrlp.AppendBigInt(dst, x.Value)
+		}
+		dst = ethrlp.AppendBytes(dst, x.Data)
+		dst = ethrlp.AppendUint64(dst, x.Nonce)
+		dst = ethrlp.AppendBytes(dst, x.To[:])
+
+		return dst
+	})
+}
+
+// DecodeRLP decodes the given RLP value into the Transaction
+func (x *Transaction) DecodeRLP(v ethrlp.Value) error {
+	list, err := ethrlp.AsList(v)
+	if err != nil {
+		return fmt.Errorf("Transaction: %w", err)
+	}
+
+	if list.Len() != 4 {
+		return fmt.Errorf("Transaction: %w: expected 4 list elements, got %d", ethrlp.ErrInvalidLength, list.Len())
+	}
+
+	values, _ := list.GetValue().([]ethrlp.Value)
+
+	// Value
+	{
+		b0, err := ethrlp.AsBytes(values[0])
+		if err != nil {
+			return fmt.Errorf("Transaction.Value: %w", err)
+		}
+
+		if x.Value, err = b0.BigInt(); err != nil {
+			return fmt.Errorf("Transaction.Value: %w", err)
+		}
+	}
+
+	// Data
+	{
+		b0, err := ethrlp.AsBytes(values[1])
+		if err != nil {
+			return fmt.Errorf("Transaction.Data: %w", err)
+		}
+
+		x.Data = make([]byte, len(b0.Bytes()))
+		copy(x.Data, b0.Bytes())
+	}
+
+	// Nonce
+	{
+		b0, err := ethrlp.AsBytes(values[2])
+		if err != nil {
+			return fmt.Errorf("Transaction.Nonce: %w", err)
+		}
+
+		v0, err := b0.Uint64()
+		if err != nil {
+			return fmt.Errorf("Transaction.Nonce: %w", err)
+		}
+
+		x.Nonce = v0
+	}
+
+	// To
+	{
+		b0, err := ethrlp.AsBytes(values[3])
+		if err != nil {
+			return fmt.Errorf("Transaction.To: %w", err)
+		}
+
+		if len(b0.Bytes()) != 20 {
+			return fmt.Errorf("Transaction.To: %w: expected 20B, got %dB", ethrlp.ErrInvalidLength, len(b0.Bytes()))
+		}
+
+		copy(x.To[:], b0.Bytes())
+	}
+
+	return nil
+}
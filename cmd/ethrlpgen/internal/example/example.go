@@ -0,0 +1,62 @@
+// Package example holds sample types used to verify the code generated by ethrlpgen
+package example
+
+import "math/big"
+
+//go:generate go run github.com/sig-0/ethrlp/cmd/ethrlpgen -type Block,Header,Transaction
+
+// Address is a 20B Ethereum address
+type Address [20]byte
+
+// Gas is a named unsigned integer type
+type Gas uint64
+
+// Label is a named string type
+type Label string
+
+// Toggle is a named bool type
+type Toggle bool
+
+// Header covers the basic field types
+type Header struct {
+	Number     *big.Int
+	Name       string
+	Label      Label
+	Extra      []byte
+	GasLimit   Gas
+	Timestamp  uint64
+	Nonce      uint32
+	Version    uint16
+	Flags      uint8
+	ParentHash [32]byte
+	Coinbase   Address
+	Final      bool
+	Sealed     Toggle
+
+	// Unexported and skipped fields are not encoded
+	cached  []byte
+	Ignored map[string]int `rlp:"-"`
+}
+
+// Transaction is a nested struct type
+type Transaction struct {
+	Value *big.Int
+	Data  []byte
+	Nonce uint64
+	To    Address
+}
+
+// Block covers nested structs, slices, arrays and types with hand-written RLP methods
+type Block struct {
+	Transactions []Transaction
+	Uncles       [][32]byte
+	Matrix       [][]uint16
+	Tags         []string
+	Amounts      []*big.Int
+	Switches     []Toggle
+	Header       Header
+	Votes        Bits
+	History      []Bits
+	Roots        [2][32]byte
+	Pair         [2]uint64
+}
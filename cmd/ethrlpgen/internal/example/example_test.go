@@ -0,0 +1,234 @@
+package example
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/sig-0/ethrlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleBlock returns a block with every field populated
+func sampleBlock() Block {
+	return Block{
+		Transactions: []Transaction{
+			{
+				Value: big.NewInt(1_000_000),
+				Data:  []byte("transfer"),
+				Nonce: 1,
+				To:    Address{0x35},
+			},
+			{
+				Value: new(big.Int).Lsh(big.NewInt(1), 100),
+				Data:  []byte{},
+				Nonce: 2,
+				To:    Address{0x01, 0x02},
+			},
+		},
+		Uncles: [][32]byte{{0xaa}, {0xbb}},
+		Matrix: [][]uint16{{1, 2}, {}, {65535}},
+		Tags:   []string{"dog", "Lorem ipsum dolor sit amet, consectetur adipisicing elit"},
+		Amounts: []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1024),
+		},
+		Switches: []Toggle{true, false},
+		Header: Header{
+			Number:     big.NewInt(19_000_000),
+			Name:       "header",
+			Label:      "mainnet",
+			Extra:      []byte{0x00, 0x01},
+			GasLimit:   30_000_000,
+			Timestamp:  1_700_000_000,
+			Nonce:      42,
+			Version:    3,
+			Flags:      0x7f,
+			ParentHash: [32]byte{0x01},
+			Coinbase:   Address{0xff},
+			Final:      true,
+			Sealed:     true,
+		},
+		Votes:   Bits{true, false, true, false, false, false, false, true},
+		History: []Bits{{}, {false, false, false, false, false, false, false, false, true}},
+		Roots:   [2][32]byte{{0x01}, {0x02}},
+		Pair:    [2]uint64{0, 1 << 40},
+	}
+}
+
+func TestGenerated_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	block := sampleBlock()
+	encoded := block.EncodeRLP()
+
+	// Make sure appending keeps the existing data
+	assert.Equal(t, append([]byte{0x01}, encoded...), block.AppendRLP([]byte{0x01}))
+
+	decoded, err := ethrlp.DecodeStrict(encoded)
+	require.NoError(t, err)
+
+	var result Block
+	require.NoError(t, result.DecodeRLP(decoded))
+
+	// The bit field length is rounded up to a multiple of 8
+	block.History[1] = append(block.History[1], make(Bits, 7)...)
+
+	assert.Equal(t, block, result)
+	assert.Equal(t, encoded, result.EncodeRLP())
+}
+
+func TestGenerated_MatchesEncoderBuffer(t *testing.T) {
+	t.Parallel()
+
+	header := sampleBlock().Header
+
+	buf := ethrlp.NewEncoderBuffer(nil)
+	index := buf.List()
+
+	buf.WriteBigInt(header.Number)
+	buf.WriteString(header.Name)
+	buf.WriteString(string(header.Label))
+	buf.WriteBytes(header.Extra)
+	buf.WriteUint64(uint64(header.GasLimit))
+	buf.WriteUint64(header.Timestamp)
+	buf.WriteUint64(uint64(header.Nonce))
+	buf.WriteUint64(uint64(header.Version))
+	buf.WriteUint64(uint64(header.Flags))
+	buf.WriteBytes(header.ParentHash[:])
+	buf.WriteBytes(header.Coinbase[:])
+	buf.WriteBool(header.Final)
+	buf.WriteBool(bool(header.Sealed))
+	buf.ListEnd(index)
+
+	assert.Equal(t, buf.ToBytes(), header.EncodeRLP())
+}
+
+func TestGenerated_HandWritten(t *testing.T) {
+	t.Parallel()
+
+	block := sampleBlock()
+
+	// The hand-written encoding is used as-is
+	assert.Equal(t, ethrlp.Encode(&block.Votes), ethrlp.EncodeBytes([]byte{0xa1}))
+
+	decoded, err := ethrlp.DecodeStrict(block.EncodeRLP())
+	require.NoError(t, err)
+
+	votes, err := ethrlp.GetBytes(decoded, 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{0xa1}, votes.Bytes())
+}
+
+func TestGenerated_NilBigInt(t *testing.T) {
+	t.Parallel()
+
+	tx := Transaction{}
+
+	decoded, err := ethrlp.DecodeStrict(tx.EncodeRLP())
+	require.NoError(t, err)
+
+	var result Transaction
+	require.NoError(t, result.DecodeRLP(decoded))
+
+	// A nil big integer is encoded as zero
+	require.NotNil(t, result.Value)
+	assert.Zero(t, result.Value.Sign())
+}
+
+func TestGenerated_DecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	// encodeBlock returns the given block value,
+	// with the element at the given index replaced
+	encodeBlock := func(index int, value ethrlp.Value) ethrlp.Value {
+		block := sampleBlock()
+
+		decoded, err := ethrlp.DecodeStrict(block.EncodeRLP())
+		require.NoError(t, err)
+
+		values, _ := decoded.GetValue().([]ethrlp.Value)
+		values[index] = value
+
+		return ethrlp.NewList(values...)
+	}
+
+	testTable := []struct {
+		input         ethrlp.Value
+		expectedErr   error
+		name          string
+		expectedLabel string
+	}{
+		{
+			ethrlp.NewBytes([]byte("block")),
+			ethrlp.ErrExpectedList,
+			"block is not a list",
+			"Block: ",
+		},
+		{
+			ethrlp.NewList(ethrlp.NewUint(1)),
+			ethrlp.ErrInvalidLength,
+			"missing block fields",
+			"Block: ",
+		},
+		{
+			encodeBlock(2, ethrlp.NewList(
+				ethrlp.NewList(),
+				ethrlp.NewList(ethrlp.NewUint(1), ethrlp.NewUint(65536)),
+			)),
+			ethrlp.ErrUintOverflow,
+			"nested integer overflow",
+			"Block.Matrix[1][1]: ",
+		},
+		{
+			encodeBlock(1, ethrlp.NewList(ethrlp.NewBytes([]byte{0x01}))),
+			ethrlp.ErrInvalidLength,
+			"byte array size mismatch",
+			"Block.Uncles[0]: ",
+		},
+		{
+			encodeBlock(0, ethrlp.NewList(ethrlp.NewList())),
+			ethrlp.ErrInvalidLength,
+			"nested struct error",
+			"Block.Transactions[0]: Transaction: ",
+		},
+		{
+			encodeBlock(3, ethrlp.NewList(ethrlp.NewList())),
+			ethrlp.ErrExpectedString,
+			"list instead of string",
+			"Block.Tags[0]: ",
+		},
+		{
+			encodeBlock(10, ethrlp.NewList(ethrlp.NewUint(1))),
+			ethrlp.ErrInvalidLength,
+			"array length mismatch",
+			"Block.Pair: ",
+		},
+		{
+			encodeBlock(9, ethrlp.NewList(ethrlp.NewBytes([]byte{0x01}), ethrlp.NewBytes([]byte{0x02}))),
+			ethrlp.ErrInvalidLength,
+			"nested byte array size mismatch",
+			"Block.Roots[0]: ",
+		},
+		{
+			encodeBlock(8, ethrlp.NewList(ethrlp.NewList())),
+			ethrlp.ErrExpectedString,
+			"hand-written method error",
+			"Block.History[0]: Bits: ",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var block Block
+
+			err := block.DecodeRLP(testCase.input)
+
+			require.ErrorIs(t, err, testCase.expectedErr)
+			assert.Contains(t, err.Error(), testCase.expectedLabel)
+		})
+	}
+}
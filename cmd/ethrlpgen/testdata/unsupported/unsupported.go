@@ -0,0 +1,24 @@
+package unsupported
+
+// Unsupported has a field type that cannot be encoded
+type Unsupported struct {
+	Values map[string]int
+}
+
+// Signed has a signed integer field
+type Signed struct {
+	Value int64
+}
+
+// Inner is a struct type without RLP methods
+type Inner struct {
+	Value uint64
+}
+
+// NestedPointer has a pointer to a struct field
+type NestedPointer struct {
+	Inner *Inner
+}
+
+// NotStruct is not a struct type
+type NotStruct []byte
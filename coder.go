@@ -0,0 +1,34 @@
+package ethrlp
+
+// RLPEncoder is implemented by types that can encode themselves to RLP.
+// Types implementing it can be added to lists (ListBuilder.Encoder,
+// EncoderBuffer.WriteEncoder), without reflection
+type RLPEncoder interface {
+	// AppendRLP appends the RLP encoding of the type to dst,
+	// and returns the extended byte array
+	AppendRLP(dst []byte) []byte
+}
+
+// RLPDecoder is implemented by types that can decode themselves from RLP.
+// Types implementing it can be decoded from bytes (Decode), or a Stream (Stream.Decode)
+type RLPDecoder interface {
+	// DecodeRLP decodes the given (decoded) RLP value into the type
+	DecodeRLP(v Value) error
+}
+
+// Encode returns the RLP encoding of the given encoder
+func Encode(e RLPEncoder) []byte {
+	return e.AppendRLP(nil)
+}
+
+// Decode decodes the given RLP bytes (as with DecodeStrict),
+// and passes the decoded value on to the given decoder.
+// Like Stream.Decode, it rejects items that are not in canonical form
+func Decode(input []byte, d RLPDecoder) error {
+	value, err := DecodeStrict(input)
+	if err != nil {
+		return err
+	}
+
+	return d.DecodeRLP(value)
+}
@@ -0,0 +1,156 @@
+package ethrlp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errInvalidPair = errors.New("invalid pair")
+
+// testPair is a sample type implementing RLPEncoder and RLPDecoder
+type testPair struct {
+	name  string
+	value uint64
+}
+
+func (p *testPair) AppendRLP(dst []byte) []byte {
+	return AppendList(dst, func(dst []byte) []byte {
+		dst = AppendString(dst, p.name)
+
+		return AppendUint64(dst, p.value)
+	})
+}
+
+func (p *testPair) DecodeRLP(v Value) error {
+	list, err := AsList(v)
+	if err != nil {
+		return err
+	}
+
+	if list.Len() != 2 {
+		return fmt.Errorf("%w: %d elements", errInvalidPair, list.Len())
+	}
+
+	name, err := GetBytes(list, 0)
+	if err != nil {
+		return err
+	}
+
+	value, err := GetBytes(list, 1)
+	if err != nil {
+		return err
+	}
+
+	p.name = name.String()
+	p.value, err = value.Uint64()
+
+	return err
+}
+
+// invalidEncoder is an RLPEncoder that produces invalid RLP
+type invalidEncoder struct{}
+
+func (invalidEncoder) AppendRLP(dst []byte) []byte {
+	return append(dst, 0x83, 0x01)
+}
+
+func TestCoder_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	pair := &testPair{
+		name:  "dog",
+		value: 1024,
+	}
+
+	encoded := Encode(pair)
+	assert.Equal(t, hexToBytes(t, "c783646f67820400"), encoded)
+
+	var decoded testPair
+	require.NoError(t, Decode(encoded, &decoded))
+
+	assert.Equal(t, *pair, decoded)
+
+	// Make sure decoding errors are propagated
+	assert.ErrorIs(t, Decode(hexToBytes(t, "c0"), &decoded), errInvalidPair)
+	assert.ErrorIs(t, Decode(hexToBytes(t, "c0c0"), &decoded), ErrTrailingBytes)
+
+	// Make sure nested items are checked for canonical encoding, as with Stream.Decode
+	assert.ErrorIs(t, Decode(hexToBytes(t, "c683646f678101"), &decoded), ErrCanonSize)
+}
+
+func TestCoder_Lists(t *testing.T) {
+	t.Parallel()
+
+	pairs := []*testPair{
+		{name: "cat", value: 1},
+		{name: "dog", value: 2},
+	}
+
+	expected := EncodeValue(NewList(
+		NewList(NewString("cat"), NewUint(1)),
+		NewList(NewString("dog"), NewUint(2)),
+	))
+
+	t.Run("list builder", func(t *testing.T) {
+		t.Parallel()
+
+		builder := NewListBuilder()
+		for _, pair := range pairs {
+			builder.Encoder(pair)
+		}
+
+		assert.Equal(t, expected, builder.Encode())
+	})
+
+	t.Run("encoder buffer", func(t *testing.T) {
+		t.Parallel()
+
+		buf := NewEncoderBuffer(nil)
+		index := buf.List()
+
+		for _, pair := range pairs {
+			buf.WriteEncoder(pair)
+		}
+
+		buf.ListEnd(index)
+
+		assert.Equal(t, expected, buf.ToBytes())
+	})
+
+	t.Run("invalid encoder output", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			NewListBuilder().Encoder(invalidEncoder{})
+		})
+	})
+}
+
+func TestCoder_StreamDecode(t *testing.T) {
+	t.Parallel()
+
+	input := hexToBytes(t, "cec783636174820400c583646f6701")
+	s := NewStream(bytes.NewReader(input), 0)
+
+	_, err := s.List()
+	require.NoError(t, err)
+
+	var first, second testPair
+
+	require.NoError(t, s.Decode(&first))
+	require.NoError(t, s.Decode(&second))
+	require.NoError(t, s.ListEnd())
+
+	assert.Equal(t, testPair{name: "cat", value: 1024}, first)
+	assert.Equal(t, testPair{name: "dog", value: 1}, second)
+
+	// Make sure nested items are checked for canonical encoding
+	s = NewStream(bytes.NewReader(hexToBytes(t, "c683646f678101")), 0)
+
+	assert.ErrorIs(t, s.Decode(&first), ErrCanonSize)
+}
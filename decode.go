@@ -3,93 +3,255 @@ package ethrlp
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 )
 
-var ErrInvalidLength = errors.New("invalid data length")
+var (
+	ErrInvalidLength      = errors.New("invalid data length")
+	ErrCanonSize          = errors.New("non-canonical size information")
+	ErrCanonInt           = errors.New("non-canonical integer format")
+	ErrNonCanonicalLength = errors.New("non-canonical length prefix")
+	ErrTrailingBytes      = errors.New("trailing bytes after RLP item")
+	ErrUintOverflow       = errors.New("uint overflow")
+	ErrEmptyInput         = errors.New("empty input")
+	ErrLengthOverflow     = errors.New("length prefix overflows int")
+)
 
-// DecodeBytes attempts to decode the given bytes from RLP
-func DecodeBytes(input []byte) (Value, error) {
-	// Fetch the top-level metadata
-	topMeta, err := getMetadata(input)
-	if err != nil {
-		return nil, err
+// DecodeError is the error returned by the decoder (DecodeBytes, DecodeStrict, SplitValue and DecodeOptions),
+// describing where the input is malformed. The underlying error is one of the package errors
+// (for example, ErrInvalidLength), and can be matched with errors.Is
+type DecodeError struct {
+	Err    error // underlying error
+	Path   []int // list indexes leading to the malformed item (nil for the top-level item)
+	Offset int   // offset (in bytes) of the malformed item in the input
+	Kind   Type  // type of the malformed item (0 if it is missing)
+}
+
+func (e *DecodeError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("unable to decode item at offset %d: %v", e.Offset, e.Err)
 	}
 
-	// Detect whether this is a list or a single byte or something else
-	var (
-		isListType   = topMeta.dataType == shortArrayType || topMeta.dataType == longArrayType
-		isSingleByte = topMeta.dataType == byteType
-	)
+	return fmt.Sprintf("unable to decode item at offset %d, path %v: %v", e.Offset, e.Path, e.Err)
+}
 
-	// Extract the payload bytes that belong to this RLP item
-	var data []byte
-	if isSingleByte {
-		// For a single-byte item, data length should be 1
-		data = input[:topMeta.dataLength]
-	} else {
-		// Otherwise, skip the first byte (+ any length-bytes),
-		// then take <data length> bytes
-		data = input[topMeta.dataOffset+1 : topMeta.dataLength+1]
-	}
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
 
-	// If it’s not a list, simply return BytesValue (byte slice)
-	if !isListType {
-		return BytesValue{value: data}, nil
-	}
+// DecodeBytes attempts to decode the given bytes from RLP.
+// The input needs to contain exactly one top-level RLP item.
+//
+// NOTE: DecodeBytes does not enforce any decoding limits.
+// Untrusted (network) input should be decoded with DefaultDecodeOptions
+func DecodeBytes(input []byte) (Value, error) {
+	return DecodeOptions{}.Decode(input)
+}
+
+// DecodeStrict attempts to decode the given bytes from RLP,
+// rejecting any item that is not in canonical (minimal) form:
+//   - single bytes in the [0x00, 0x7f] range wrapped in a string header (ErrCanonSize)
+//   - length fields with leading zero bytes (ErrCanonInt)
+//   - long-form headers used for payloads of 55 bytes or less (ErrNonCanonicalLength)
+func DecodeStrict(input []byte) (Value, error) {
+	return DecodeOptions{Strict: true}.Decode(input)
+}
+
+// SplitValue decodes the first RLP item from the given bytes,
+// and returns it along with the remaining (unconsumed) input.
+// It can be used to parse a stream of concatenated RLP items
+func SplitValue(input []byte) (Value, []byte, error) {
+	return DecodeOptions{}.SplitValue(input)
+}
+
+// decodeFrame is a list that is being decoded
+type decodeFrame struct {
+	content []byte // list content that is yet to be decoded
+	offset  int    // offset of the list content that is yet to be decoded, in the input
+	start   int    // position of the first list element in the element scratch space
+}
 
+// decodeBytes decodes the first RLP item in the given bytes,
+// enforcing the decoding options for every decoded item.
+// The input bytes that follow the decoded item are returned as-is.
+//
+// Nested lists are decoded using an explicit stack of open lists (instead of recursion),
+// so the (goroutine) stack usage does not depend on the input nesting depth.
+// The elements of all open lists share a single scratch space, and every
+// list is copied out of it (into an exactly sized slice) once it is finished
+func decodeBytes(input []byte, opts DecodeOptions) (Value, []byte, error) {
 	var (
-		// Calculate how many data bytes we have in this list
-		// (excludes the prefix & length bytes)
-		listLength   = topMeta.dataLength - topMeta.dataOffset
-		decodedItems = make([]Value, 0, 9) // 9 is chosen as a common Ethereum RLP list size
+		// The initial stack and scratch space are expected
+		// to fit most inputs, without additional allocations
+		stackBuf   [8]decodeFrame
+		scratchBuf [32]Value
+
+		stack   = stackBuf[:0]
+		scratch = scratchBuf[:0] // decoded elements of the open lists
+		rest    []byte           // input that follows the top-level item
 	)
 
-	// Parse each element of the list
-	for parseIndex := 0; parseIndex < listLength; {
-		// Get metadata on the element
-		elemMeta, err := getMetadata(data[parseIndex:])
+	for {
+		// Fetch the bytes the next item is decoded from
+		var (
+			source = input
+			offset = 0
+		)
+
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+
+			if opts.MaxListElements > 0 && len(scratch)-top.start == opts.MaxListElements {
+				return nil, nil, newDecodeError(
+					fmt.Errorf("%w: limit %d", ErrMaxListElements, opts.MaxListElements),
+					stack,
+					len(scratch),
+					top.offset,
+					top.content,
+				)
+			}
+
+			source, offset = top.content, top.offset
+		}
+
+		kind, content, itemRest, err := splitItem(source, opts.Strict)
 		if err != nil {
-			return nil, err
+			return nil, nil, newDecodeError(err, stack, len(scratch), offset, source)
 		}
 
-		// Calculate the total on-wire size of this element.
-		// A single byte in [0x00..0x7f] is its *own* entire encoding (no extra prefix)
-		itemTotal := 1
-		if elemMeta.dataType != byteType {
-			// For bytes / lists, the total is prefix byte(s) + data length
-			itemTotal = 1 + elemMeta.dataLength
+		// Consume the item from its source
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+
+			top.content = itemRest
+			top.offset += len(source) - len(itemRest)
+		} else {
+			rest = itemRest
 		}
 
-		// Check range bounds
-		if parseIndex+itemTotal > len(data) {
-			return nil, fmt.Errorf(
-				"RLP data is truncated: parse index =%d total items=%d data length=%d",
-				parseIndex, itemTotal, len(data),
-			)
+		var value Value
+
+		if kind == Bytes {
+			value = BytesValue{value: content}
+		} else {
+			if opts.MaxDepth > 0 && len(stack) == opts.MaxDepth {
+				return nil, nil, newDecodeError(
+					fmt.Errorf("%w: limit %d", ErrMaxDepth, opts.MaxDepth),
+					stack,
+					len(scratch),
+					offset,
+					source,
+				)
+			}
+
+			// The list elements are decoded in the following iterations
+			stack = append(stack, decodeFrame{
+				content: content,
+				offset:  offset + len(source) - len(itemRest) - len(content),
+				start:   len(scratch),
+			})
 		}
 
-		// Extract the sub-slice for this item
-		itemBytes := data[parseIndex : parseIndex+itemTotal]
+		// Add the decoded value to its list,
+		// and finish all the lists that are fully decoded
+		for {
+			if value == nil {
+				top := stack[len(stack)-1]
+				if len(top.content) > 0 {
+					// The list has more elements
+					break
+				}
+
+				values := make([]Value, len(scratch)-top.start)
+				copy(values, scratch[top.start:])
+
+				value = ListValue{values: values}
+				stack = stack[:len(stack)-1]
+				scratch = scratch[:top.start]
+			}
+
+			if len(stack) == 0 {
+				return value, rest, nil
+			}
+
+			scratch = append(scratch, value)
+			value = nil
+		}
+	}
+}
 
-		// Decode the item recursively
-		decodedItem, err := DecodeBytes(itemBytes)
-		if err != nil {
-			return nil, fmt.Errorf("unable to decode item, %w", err)
+// newDecodeError constructs the decode error of the item that starts
+// at the given offset, and is the next element of the innermost open list
+// (scratchLen is the number of decoded elements of the open lists)
+func newDecodeError(err error, stack []decodeFrame, scratchLen, offset int, item []byte) *DecodeError {
+	var path []int
+
+	if len(stack) > 0 {
+		path = make([]int, len(stack))
+
+		// The elements of every open list are followed
+		// in the scratch space by the elements of the nested list
+		for index := range stack {
+			end := scratchLen
+			if index+1 < len(stack) {
+				end = stack[index+1].start
+			}
+
+			path[index] = end - stack[index].start
 		}
+	}
+
+	return &DecodeError{
+		Err:    err,
+		Path:   path,
+		Offset: offset,
+		Kind:   itemKind(item),
+	}
+}
+
+// itemKind returns the type of the RLP item
+// at the start of the given bytes (0 if there is none)
+func itemKind(item []byte) Type {
+	switch {
+	case len(item) == 0:
+		return 0
+	case item[0] >= 0xc0:
+		return List
+	default:
+		return Bytes
+	}
+}
 
-		// Save the decoded item
-		decodedItems = append(decodedItems, decodedItem)
+// splitItem returns the type and content of the first RLP item in the given bytes,
+// along with the bytes that follow it, optionally enforcing canonical encoding
+// of the item header
+func splitItem(input []byte, strict bool) (Type, []byte, []byte, error) {
+	// Fetch the top-level metadata
+	meta, err := getMetadata(input)
+	if err != nil {
+		return 0, nil, nil, err
+	}
 
-		// Move to the next element
-		parseIndex += itemTotal
+	if strict {
+		if err := checkCanonical(input, meta); err != nil {
+			return 0, nil, nil, err
+		}
 	}
 
-	return ListValue{values: decodedItems}, nil
+	switch meta.dataType {
+	case byteType:
+		// A single byte in [0x00..0x7f] is its own content
+		return Bytes, input[:1], input[1:], nil
+	case shortBytesType, longBytesType:
+		return Bytes, input[meta.dataOffset+1 : meta.dataLength+1], input[meta.dataLength+1:], nil
+	default:
+		return List, input[meta.dataOffset+1 : meta.dataLength+1], input[meta.dataLength+1:], nil
+	}
 }
 
 const (
-	emptyType = iota
-	byteType
+	byteType = iota
 	shortBytesType
 	longBytesType
 	shortArrayType
@@ -105,12 +267,53 @@ type metadata struct {
 // getMetadata returns the metadata about the top-level RLP type
 func getMetadata(data []byte) (metadata, error) {
 	if len(data) == 0 {
-		return metadata{
-			dataType: emptyType,
-		}, nil
+		// The empty input error is also a length error
+		return metadata{}, fmt.Errorf("%w: %w", ErrEmptyInput, constructLengthError(1, 0))
+	}
+
+	meta, err := parseHeader(data)
+	if err != nil {
+		return metadata{}, err
+	}
+
+	// A single byte value is its own data
+	if meta.dataType == byteType {
+		return meta, nil
 	}
 
-	firstByte := data[0]
+	// Make sure the data fits in the input
+	var (
+		length    = meta.dataLength - meta.dataOffset
+		available = len(data) - 1 - meta.dataOffset
+	)
+
+	if length > available {
+		return metadata{}, constructLengthError(length, available)
+	}
+
+	return meta, nil
+}
+
+// headerSize returns the total size of the RLP header (in bytes)
+// that starts with the given byte, including the byte itself
+func headerSize(firstByte byte) int {
+	switch {
+	case firstByte > 0xb7 && firstByte <= 0xbf:
+		// Long bytes
+		return 1 + int(firstByte-0xb7)
+	case firstByte > 0xf7:
+		// Long array
+		return 1 + int(firstByte-0xf7)
+	default:
+		return 1
+	}
+}
+
+// parseHeader parses the RLP header at the start of the given data.
+// Only the header bytes are required to be present, the data
+// they describe is not validated
+func parseHeader(header []byte) (metadata, error) {
+	firstByte := header[0]
 
 	switch {
 	case firstByte <= 0x7f:
@@ -122,28 +325,21 @@ func getMetadata(data []byte) (metadata, error) {
 		}, nil
 	case firstByte > 0x7f && firstByte <= 0xb7:
 		// Short bytes
-		length := int(firstByte - 0x80)
-
-		if length > len(data)-1 {
-			return metadata{}, constructLengthError(length, len(data)-1)
-		}
-
 		return metadata{
 			dataType:   shortBytesType,
 			dataOffset: 0,
-			dataLength: length,
+			dataLength: int(firstByte - 0x80),
 		}, nil
 	case firstByte > 0xb7 && firstByte <= 0xbf:
 		// Long bytes
 		lengthBytes := int(firstByte - 0xb7)
-		if lengthBytes > len(data)-1 {
-			return metadata{}, constructLengthError(lengthBytes, len(data)-1)
+		if lengthBytes > len(header)-1 {
+			return metadata{}, constructLengthError(lengthBytes, len(header)-1)
 		}
 
-		length := convertHexArrayToInt(data[1 : lengthBytes+1])
-
-		if length > len(data)-1-lengthBytes {
-			return metadata{}, constructLengthError(length, len(data)-1-lengthBytes)
+		length, err := decodeLength(header[1 : lengthBytes+1])
+		if err != nil {
+			return metadata{}, err
 		}
 
 		return metadata{
@@ -153,27 +349,21 @@ func getMetadata(data []byte) (metadata, error) {
 		}, nil
 	case firstByte > 0xbf && firstByte <= 0xf7:
 		// Short array
-		length := int(firstByte - 0xc0)
-		if length > len(data)-1 {
-			return metadata{}, constructLengthError(length, len(data)-1)
-		}
-
 		return metadata{
 			dataType:   shortArrayType,
 			dataOffset: 0,
-			dataLength: length,
+			dataLength: int(firstByte - 0xc0),
 		}, nil
 	default:
 		// Long array
 		lengthBytes := int(firstByte - 0xf7)
-		if lengthBytes > len(data)-1 {
-			return metadata{}, constructLengthError(lengthBytes, len(data)-1)
+		if lengthBytes > len(header)-1 {
+			return metadata{}, constructLengthError(lengthBytes, len(header)-1)
 		}
 
-		length := convertHexArrayToInt(data[1 : lengthBytes+1])
-
-		if length > len(data)-1-lengthBytes {
-			return metadata{}, constructLengthError(length, len(data)-1-lengthBytes)
+		length, err := decodeLength(header[1 : lengthBytes+1])
+		if err != nil {
+			return metadata{}, err
 		}
 
 		return metadata{
@@ -184,20 +374,75 @@ func getMetadata(data []byte) (metadata, error) {
 	}
 }
 
-// convertHexArrayToInt converts the byte array of hex values
-// to its corresponding integer representation
-func convertHexArrayToInt(hexArray []byte) int {
-	length := 0
+// checkCanonical verifies that the top-level RLP header
+// of the given data is in its canonical (minimal) form
+func checkCanonical(data []byte, meta metadata) error {
+	switch meta.dataType {
+	case shortBytesType:
+		// A single byte in the [0x00, 0x7f] range
+		// is its own encoding, and cannot be prefixed
+		if meta.dataLength == 1 && data[1] <= 0x7f {
+			return ErrCanonSize
+		}
+	case longBytesType, longArrayType:
+		// The length field cannot contain leading zeros
+		if data[1] == 0 {
+			return ErrCanonInt
+		}
+
+		// The long form is reserved for payloads over 55B
+		if meta.dataLength-meta.dataOffset <= 55 {
+			return ErrNonCanonicalLength
+		}
+	}
+
+	return nil
+}
+
+// decodeUint64 decodes the given big-endian integer bytes,
+// enforcing the RLP integer rules (no leading zeros, at most 8B)
+func decodeUint64(input []byte) (uint64, error) {
+	if len(input) > 8 {
+		return 0, fmt.Errorf("%w: %dB", ErrUintOverflow, len(input))
+	}
+
+	if len(input) > 0 && input[0] == 0 {
+		return 0, ErrCanonInt
+	}
+
+	var value uint64
+
+	for _, b := range input {
+		value = value<<8 | uint64(b)
+	}
+
+	return value, nil
+}
+
+// decodeBigInt decodes the given big-endian integer bytes,
+// enforcing the RLP integer rules (no leading zeros)
+func decodeBigInt(input []byte) (*big.Int, error) {
+	if len(input) > 0 && input[0] == 0 {
+		return nil, ErrCanonInt
+	}
+
+	return new(big.Int).SetBytes(input), nil
+}
+
+// decodeLength decodes the given big-endian length bytes (of a long header).
+// The length needs to fit into an int, along with the header
+func decodeLength(lengthBytes []byte) (int, error) {
+	var length uint64
 
-	for _, b := range hexArray {
-		// Shift the current length value 8 bits to the left
-		length <<= 8
+	for _, b := range lengthBytes {
+		length = length<<8 | uint64(b)
+	}
 
-		// Add the current byte to the length
-		length |= int(b)
+	if length > uint64(math.MaxInt-maxHeaderSize) {
+		return 0, fmt.Errorf("%w: %d", ErrLengthOverflow, length)
 	}
 
-	return length
+	return int(length), nil
 }
 
 // constructLengthError constructs an invalid RLP length error
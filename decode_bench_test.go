@@ -160,3 +160,18 @@ func BenchmarkDecode_Array_Nested_Long(b *testing.B) {
 	b.ResetTimer()
 	benchmarkDecodeCommon(b, encoding)
 }
+
+func BenchmarkDecode_Array_Nested_Deep(b *testing.B) {
+	// 512 nested lists, each holding a string and the next list
+	encoding := EncodeString("leaf")
+
+	for i := 0; i < 512; i++ {
+		encoding = EncodeArray([][]byte{
+			EncodeString("asdf"),
+			encoding,
+		})
+	}
+
+	b.ResetTimer()
+	benchmarkDecodeCommon(b, encoding)
+}
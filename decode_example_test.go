@@ -1,6 +1,9 @@
 package ethrlp
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 func ExampleDecodeBytes() {
 	// Byte value
@@ -14,3 +17,43 @@ func ExampleDecodeBytes() {
 	// Output:
 	// 00 Bytes
 }
+
+func ExampleDecodeStrict() {
+	// Single byte value, wrapped in a string header
+	_, err := DecodeStrict([]byte{0x81, 0x05})
+
+	fmt.Println(err)
+
+	// Output:
+	// unable to decode item at offset 0: non-canonical size information
+}
+
+func ExampleDecodeOptions() {
+	opts := DecodeOptions{
+		MaxDepth: 2,
+	}
+
+	// Three nested lists
+	_, err := opts.Decode([]byte{0xC2, 0xC1, 0xC0})
+
+	fmt.Println(errors.Is(err, ErrMaxDepth))
+
+	// Output:
+	// true
+}
+
+func ExampleDecodeError() {
+	// The second element of the nested list is truncated
+	_, err := DecodeBytes([]byte{0xC6, 0x05, 0xC4, 0x01, 0x83, 0x64, 0x6F})
+
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		fmt.Println(decodeErr.Offset, decodeErr.Path, decodeErr.Kind)
+	}
+
+	fmt.Println(errors.Is(err, ErrInvalidLength))
+
+	// Output:
+	// 4 [1 1] Bytes
+	// true
+}
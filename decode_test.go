@@ -862,3 +862,363 @@ func TestDecode_EIP155(t *testing.T) {
 
 	assert.Zero(t, expectedS.Cmp(s))
 }
+
+func TestDecode_Strict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("canonical input", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name  string
+			input []byte
+		}{
+			{
+				"single byte",
+				hexToBytes(t, "05"),
+			},
+			{
+				"single byte above 0x7f",
+				hexToBytes(t, "8180"),
+			},
+			{
+				"short string",
+				hexToBytes(t, "83646f67"),
+			},
+			{
+				"long string",
+				EncodeString("Lorem ipsum dolor sit amet, consectetur adipisicing elit"),
+			},
+			{
+				"nested list",
+				hexToBytes(t, "c7c0c1c0c3c0c1c0"),
+			},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				strictValue, err := DecodeStrict(testCase.input)
+				require.NoError(t, err)
+
+				value, err := DecodeBytes(testCase.input)
+				require.NoError(t, err)
+
+				assert.Equal(t, value, strictValue)
+			})
+		}
+	})
+
+	t.Run("non-canonical input", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			expectedErr error
+			name        string
+			input       []byte
+		}{
+			{
+				ErrCanonSize,
+				"single byte wrapped in a string header",
+				hexToBytes(t, "8105"),
+			},
+			{
+				ErrCanonSize,
+				"nested single byte wrapped in a string header",
+				hexToBytes(t, "c3018100"),
+			},
+			{
+				ErrNonCanonicalLength,
+				"long string header for short string",
+				hexToBytes(t, "b803646f67"),
+			},
+			{
+				ErrNonCanonicalLength,
+				"long list header for short list",
+				hexToBytes(t, "f80483646f67"),
+			},
+			{
+				ErrCanonInt,
+				"long string length with leading zeros",
+				append(hexToBytes(t, "b90038"), make([]byte, 56)...),
+			},
+			{
+				ErrCanonInt,
+				"long list length with leading zeros",
+				append(hexToBytes(t, "f90038"), make([]byte, 56)...),
+			},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				// Make sure the lenient decoder accepts the input
+				_, err := DecodeBytes(testCase.input)
+				require.NoError(t, err)
+
+				_, err = DecodeStrict(testCase.input)
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			})
+		}
+	})
+}
+
+func TestDecode_TrailingBytes(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name  string
+		input []byte
+	}{
+		{
+			"single byte",
+			hexToBytes(t, "0505"),
+		},
+		{
+			"short string",
+			hexToBytes(t, "83646f67ffff"),
+		},
+		{
+			"list",
+			hexToBytes(t, "c0c0"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeBytes(testCase.input)
+			assert.ErrorIs(t, err, ErrTrailingBytes)
+
+			_, err = DecodeStrict(testCase.input)
+			assert.ErrorIs(t, err, ErrTrailingBytes)
+		})
+	}
+}
+
+func TestDecode_SplitValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("concatenated items", func(t *testing.T) {
+		t.Parallel()
+
+		input := hexToBytes(t, "83646f67c88363617483646f6705")
+
+		// Decode the string
+		value, rest, err := SplitValue(input)
+		require.NoError(t, err)
+
+		assert.Equal(t, BytesValue{value: []byte("dog")}, value)
+
+		// Decode the list
+		value, rest, err = SplitValue(rest)
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			ListValue{
+				values: []Value{
+					BytesValue{value: []byte("cat")},
+					BytesValue{value: []byte("dog")},
+				},
+			},
+			value,
+		)
+
+		// Decode the single byte
+		value, rest, err = SplitValue(rest)
+		require.NoError(t, err)
+
+		assert.Equal(t, BytesValue{value: []byte{0x05}}, value)
+		assert.Empty(t, rest)
+	})
+
+	t.Run("invalid item", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := SplitValue(hexToBytes(t, "83646f"))
+		assert.ErrorIs(t, err, ErrInvalidLength)
+	})
+}
+
+func TestDecode_DeepNesting(t *testing.T) {
+	t.Parallel()
+
+	const depth = 1 << 18
+
+	// Compute the content size of every nested list, starting from the innermost one
+	sizes := make([]int, depth)
+	for i := depth - 2; i >= 0; i-- {
+		sizes[i] = headerLength(sizes[i+1]) + sizes[i+1]
+	}
+
+	input := make([]byte, 0, headerLength(sizes[0])+sizes[0])
+	for _, size := range sizes {
+		input = AppendListHeader(input, size)
+	}
+
+	// Make sure the decoder stack does not depend on the nesting depth
+	value, err := DecodeBytes(input)
+	require.NoError(t, err)
+
+	for i := 1; i < depth; i++ {
+		list, ok := value.(ListValue)
+		require.True(t, ok)
+		require.Equal(t, 1, list.Len())
+
+		value = list.values[0]
+	}
+
+	assert.Equal(t, NewList(), value)
+}
+
+func TestDecode_DecodeError(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		expectedErr    error
+		name           string
+		input          []byte
+		expectedPath   []int
+		opts           DecodeOptions
+		expectedOffset int
+		expectedKind   Type
+	}{
+		{
+			ErrInvalidLength,
+			"truncated top-level string",
+			hexToBytes(t, "83646f"),
+			nil,
+			DecodeOptions{},
+			0,
+			Bytes,
+		},
+		{
+			ErrInvalidLength,
+			"truncated nested string",
+			hexToBytes(t, "c5c4c0c28305"),
+			[]int{0, 1, 0},
+			DecodeOptions{},
+			4,
+			Bytes,
+		},
+		{
+			ErrCanonSize,
+			"non-canonical list element",
+			hexToBytes(t, "c3018100"),
+			[]int{1},
+			DecodeOptions{Strict: true},
+			2,
+			Bytes,
+		},
+		{
+			ErrMaxListElements,
+			"too many list elements",
+			hexToBytes(t, "c3010203"),
+			[]int{2},
+			DecodeOptions{MaxListElements: 2},
+			3,
+			Bytes,
+		},
+		{
+			ErrMaxDepth,
+			"nested list too deep",
+			hexToBytes(t, "c2c180"),
+			[]int{0},
+			DecodeOptions{MaxDepth: 1},
+			1,
+			List,
+		},
+		{
+			ErrTrailingBytes,
+			"trailing list",
+			hexToBytes(t, "c0c0"),
+			nil,
+			DecodeOptions{},
+			1,
+			List,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testCase.opts.Decode(testCase.input)
+			require.ErrorIs(t, err, testCase.expectedErr)
+
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+
+			assert.Equal(t, testCase.expectedPath, decodeErr.Path)
+			assert.Equal(t, testCase.expectedOffset, decodeErr.Offset)
+			assert.Equal(t, testCase.expectedKind, decodeErr.Kind)
+		})
+	}
+}
+
+func TestDecode_MalformedInput(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		expectedErr error
+		name        string
+		input       []byte
+	}{
+		{
+			ErrEmptyInput,
+			"empty input",
+			[]byte{},
+		},
+		{
+			ErrLengthOverflow,
+			"long string length overflow",
+			hexToBytes(t, "bfffffffffffffffff00"),
+		},
+		{
+			ErrLengthOverflow,
+			"long list length overflow",
+			hexToBytes(t, "ffffffffffffffffff00"),
+		},
+		{
+			ErrLengthOverflow,
+			"nested long string length overflow",
+			hexToBytes(t, "c9bf8000000000000000"),
+		},
+		{
+			ErrInvalidLength,
+			"missing length bytes",
+			hexToBytes(t, "bb0100"),
+		},
+		{
+			ErrInvalidLength,
+			"long list length over the input size",
+			hexToBytes(t, "fb7fffffff00"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeBytes(testCase.input)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+
+			_, err = DecodeStrict(testCase.input)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+
+			_, _, err = SplitValue(testCase.input)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+
+	t.Run("empty input is a length error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecodeBytes(nil)
+		assert.ErrorIs(t, err, ErrInvalidLength)
+	})
+}
@@ -6,4 +6,13 @@
 // This library intentionally does not expose an `any` API for encoding, since it wants to avoid using reflection.
 // The consequence of this is that the package caller will need to manually encode specific struct fields, array values,
 // using the provided encode methods.
+//
+// RLP has no representation for negative integers, so EncodeInt, EncodeBigInt and AppendBigInt (and the functions
+// built on them) panic on negative input, instead of silently encoding its absolute value. Values that are not known
+// to be valid should be encoded with the error-returning EncodeIntE and EncodeBigIntE.
+//
+// For struct types, these methods can be generated with the ethrlpgen command (cmd/ethrlpgen).
+//
+// Encoded data can be hashed with the (dependency-free) Keccak-256 implementation, either directly (HashOf),
+// or while it is written out by an EncoderBuffer (EncoderBuffer.Hash, or a Keccak256 hasher as its writer).
 package ethrlp
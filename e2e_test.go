@@ -1,6 +1,7 @@
 package ethrlp
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -246,3 +247,95 @@ func TestEncodeDecode_Array_Nested(t *testing.T) {
 		}
 	}
 }
+
+// canonicalVectors returns canonical RLP encodings,
+// taken from the Ethereum RLP test vectors
+func canonicalVectors(t testingT) [][]byte {
+	t.Helper()
+
+	return [][]byte{
+		hexToBytes(t, "00"),
+		hexToBytes(t, "01"),
+		hexToBytes(t, "7f"),
+		hexToBytes(t, "80"),
+		hexToBytes(t, "8180"),
+		hexToBytes(t, "8203e8"),
+		hexToBytes(t, "830186a0"),
+		hexToBytes(t, "83646f67"),
+		hexToBytes(t, "8f102030405060708090a0b0c0d0e0f2"),
+		hexToBytes(t, "9c0100020003000400050006000700080009000a000b000c000d000e01"),
+		hexToBytes(t, "a1010000000000000000000000000000000000000000000000000000000000000000"),
+		hexToBytes(t, "b74c6f72656d20697073756d20646f6c6f722073697420616d65742c20636f6e7365637465747572206164697069736963696e6720656c69"),
+		hexToBytes(t, "b8384c6f72656d20697073756d20646f6c6f722073697420616d65742c20636f6e7365637465747572206164697069736963696e6720656c6974"),
+		hexToBytes(t, "c0"),
+		hexToBytes(t, "c4c2c0c0c0"),
+		hexToBytes(t, "c6827a77c10401"),
+		hexToBytes(t, "c7c0c1c0c3c0c1c0"),
+		hexToBytes(t, "cc83646f6783676f6483636174"),
+		hexToBytes(t, "ecca846b6579318476616c31ca846b6579328476616c32ca846b6579338476616c33ca846b6579348476616c34"),
+		hexToBytes(t, "f784617364668471776572847a78637684617364668471776572847a78637684617364668471776572847a78637684617364668471776572"),
+		hexToBytes(t, "f840cf84617364668471776572847a786376cf84617364668471776572847a786376cf84617364668471776572847a786376cf84617364668471776572847a786376"),
+		hexToBytes(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+	}
+}
+
+// randomEncoding generates a random canonical RLP encoding,
+// with lists nested up to the given depth
+func randomEncoding(r *rand.Rand, depth int) []byte {
+	if depth == 0 || r.Intn(3) == 0 {
+		// Generate a byte string, of up to 80B
+		value := make([]byte, r.Intn(80))
+		r.Read(value)
+
+		return EncodeBytes(value)
+	}
+
+	// Generate a list, of up to 8 elements
+	elements := make([][]byte, r.Intn(8))
+	for i := range elements {
+		elements[i] = randomEncoding(r, depth-1)
+	}
+
+	return EncodeArray(elements)
+}
+
+func TestEncodeDecode_Value_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("test vectors", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range canonicalVectors(t) {
+			value, err := DecodeStrict(input)
+			require.NoError(t, err)
+
+			assert.Equal(t, input, EncodeValue(value))
+		}
+	})
+
+	t.Run("random encodings", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(42))
+
+		for i := 0; i < 500; i++ {
+			input := randomEncoding(r, 4)
+
+			value, err := DecodeStrict(input)
+			require.NoError(t, err)
+
+			assert.Equal(t, input, EncodeValue(value))
+		}
+	})
+
+	t.Run("append to existing buffer", func(t *testing.T) {
+		t.Parallel()
+
+		input := hexToBytes(t, "c7c0c1c0c3c0c1c0")
+
+		value, err := DecodeBytes(input)
+		require.NoError(t, err)
+
+		assert.Equal(t, append([]byte{0x01}, input...), AppendValue([]byte{0x01}, value))
+	})
+}
@@ -1,12 +1,21 @@
 package ethrlp
 
-import "math/big"
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
 
 var (
 	EmptyBytes = []byte{0x80}
 	EmptyArray = []byte{0xC0}
 )
 
+var (
+	ErrNegativeBigInt = errors.New("cannot encode negative integer")
+	ErrNilBigInt      = errors.New("cannot encode nil big integer")
+)
+
 // EncodeBool encodes a single bool to RLP
 func EncodeBool(input bool) []byte {
 	if input {
@@ -16,19 +25,61 @@ func EncodeBool(input bool) []byte {
 	return EmptyBytes
 }
 
-// EncodeInt encodes an int64 to RLP
+// EncodeInt encodes an int64 to RLP.
+// RLP has no representation for negative integers,
+// so EncodeInt panics if the input is negative (see EncodeIntE)
 func EncodeInt(input int64) []byte {
-	return EncodeBigInt(big.NewInt(input))
+	if input < 0 {
+		panic(negativeIntError(input))
+	}
+
+	return EncodeUint(uint64(input))
+}
+
+// EncodeIntE encodes an int64 to RLP,
+// returning ErrNegativeBigInt if the input is negative
+func EncodeIntE(input int64) ([]byte, error) {
+	if input < 0 {
+		return nil, negativeIntError(input)
+	}
+
+	return EncodeUint(uint64(input)), nil
 }
 
 // EncodeUint encodes a uint64 to RLP
 func EncodeUint(input uint64) []byte {
-	return EncodeBigInt(big.NewInt(0).SetUint64(input))
+	// The integer bytes are written directly,
+	// without going through big.Int
+	return AppendUint64(make([]byte, 0, 1+intSize(input)), input)
 }
 
-// EncodeBigInt encodes a big.Int to RLP
+// EncodeBigInt encodes a big.Int to RLP.
+// RLP has no representation for negative integers,
+// so EncodeBigInt panics if the input is negative or nil (see EncodeBigIntE)
 func EncodeBigInt(input *big.Int) []byte {
-	return EncodeBytes(input.Bytes())
+	if err := checkBigInt(input); err != nil {
+		panic(err)
+	}
+
+	return encodeBigInt(input)
+}
+
+// EncodeBigIntE encodes a big.Int to RLP, returning
+// ErrNegativeBigInt if the input is negative or nil.
+// A nil input error additionally wraps ErrNilBigInt
+func EncodeBigIntE(input *big.Int) ([]byte, error) {
+	if err := checkBigInt(input); err != nil {
+		return nil, err
+	}
+
+	return encodeBigInt(input), nil
+}
+
+// encodeBigInt encodes the given (valid) big integer to RLP
+func encodeBigInt(input *big.Int) []byte {
+	size := (input.BitLen() + 7) / 8
+
+	return appendBigInt(make([]byte, 0, headerLength(size)+size), input)
 }
 
 // EncodeString encodes a string to RLP
@@ -210,3 +261,237 @@ func encodeLongArray(input [][]byte, combinedLength int) []byte {
 
 	return result
 }
+
+// AppendBool appends the RLP encoding of the given bool to dst,
+// and returns the extended byte array
+func AppendBool(dst []byte, input bool) []byte {
+	if input {
+		return append(dst, 0x01)
+	}
+
+	return append(dst, 0x80)
+}
+
+// AppendByte appends the RLP encoding of the given byte to dst,
+// and returns the extended byte array
+func AppendByte(dst []byte, input byte) []byte {
+	// If input is a single byte in the [0x00, 0x7f] range,
+	// it itself is the RLP encoding
+	if input <= 0x7f {
+		return append(dst, input)
+	}
+
+	return append(dst, 0x81, input)
+}
+
+// AppendBytes appends the RLP encoding of the given byte array to dst,
+// and returns the extended byte array
+func AppendBytes(dst, input []byte) []byte {
+	if len(input) == 1 {
+		return AppendByte(dst, input[0])
+	}
+
+	dst = appendHeader(dst, 0x80, len(input))
+
+	return append(dst, input...)
+}
+
+// AppendString appends the RLP encoding of the given string to dst,
+// and returns the extended byte array
+func AppendString(dst []byte, input string) []byte {
+	if len(input) == 1 {
+		return AppendByte(dst, input[0])
+	}
+
+	dst = appendHeader(dst, 0x80, len(input))
+
+	return append(dst, input...)
+}
+
+// AppendUint64 appends the RLP encoding of the given unsigned integer to dst,
+// and returns the extended byte array
+func AppendUint64(dst []byte, input uint64) []byte {
+	switch {
+	case input == 0:
+		// Zero is encoded as an empty byte string
+		return append(dst, 0x80)
+	case input <= 0x7f:
+		return append(dst, byte(input))
+	default:
+		size := intSize(input)
+
+		dst = append(dst, 0x80+byte(size))
+
+		return appendBigEndian(dst, input, size)
+	}
+}
+
+// AppendBigInt appends the RLP encoding of the given big integer to dst,
+// and returns the extended byte array.
+// AppendBigInt panics if the input is negative or nil
+func AppendBigInt(dst []byte, input *big.Int) []byte {
+	if err := checkBigInt(input); err != nil {
+		panic(err)
+	}
+
+	return appendBigInt(dst, input)
+}
+
+// appendBigInt appends the RLP encoding of the given (valid) big integer to dst
+func appendBigInt(dst []byte, input *big.Int) []byte {
+	if input.IsUint64() {
+		return AppendUint64(dst, input.Uint64())
+	}
+
+	// Write the integer bytes directly into the array
+	size := (input.BitLen() + 7) / 8
+
+	dst = appendHeader(dst, 0x80, size)
+	dst = append(dst, make([]byte, size)...)
+
+	input.FillBytes(dst[len(dst)-size:])
+
+	return dst
+}
+
+// AppendListHeader appends the RLP header of a list
+// with the given content size (in bytes) to dst,
+// and returns the extended byte array.
+// The list content (RLP-encoded elements) is expected to follow
+func AppendListHeader(dst []byte, size int) []byte {
+	return appendHeader(dst, 0xc0, size)
+}
+
+// AppendList appends an RLP list to dst, and returns the extended byte array.
+// The list elements are appended by the given callback, so the list
+// header can be written without the elements being encoded separately
+func AppendList(dst []byte, appendElems func([]byte) []byte) []byte {
+	// Reserve the space for a short list header
+	start := len(dst)
+	dst = appendElems(append(dst, 0))
+
+	size := len(dst) - start - 1
+	if size <= 55 {
+		dst[start] = 0xc0 + byte(size)
+
+		return dst
+	}
+
+	// The list is long, so the content needs
+	// to be moved to make room for the size bytes
+	sizeBytes := intSize(uint64(size))
+
+	dst = append(dst, make([]byte, sizeBytes)...)
+	copy(dst[start+1+sizeBytes:], dst[start+1:start+1+size])
+
+	appendHeader(dst[start:start], 0xc0, size)
+
+	return dst
+}
+
+// checkBigInt verifies the given big integer can be encoded to RLP.
+// Both negative and nil integers are reported as ErrNegativeBigInt
+func checkBigInt(input *big.Int) error {
+	if input == nil {
+		return fmt.Errorf("%w: %w", ErrNegativeBigInt, ErrNilBigInt)
+	}
+
+	if input.Sign() < 0 {
+		return fmt.Errorf("%w: %s", ErrNegativeBigInt, input)
+	}
+
+	return nil
+}
+
+// negativeIntError constructs a negative integer error
+func negativeIntError(input int64) error {
+	return fmt.Errorf("%w: %d", ErrNegativeBigInt, input)
+}
+
+// appendHeader appends the RLP header for a byte string (base 0x80)
+// or a list (base 0xc0) with the given content size
+func appendHeader(dst []byte, base byte, size int) []byte {
+	// Content of up to 55 bytes has a single byte header,
+	// with the content size added to the base
+	if size <= 55 {
+		return append(dst, base+byte(size))
+	}
+
+	// Otherwise, the header holds the number of size bytes
+	// added to the long base (base + 55), followed by the size bytes
+	sizeBytes := intSize(uint64(size))
+
+	dst = append(dst, base+55+byte(sizeBytes))
+
+	return appendBigEndian(dst, uint64(size), sizeBytes)
+}
+
+// appendBigEndian appends the lowest n bytes
+// of the given value, in big-endian order
+func appendBigEndian(dst []byte, value uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, byte(value>>(8*uint(i))))
+	}
+
+	return dst
+}
+
+// intSize returns the minimal number of bytes
+// required to represent the given value
+func intSize(value uint64) int {
+	size := 0
+
+	for ; value > 0; value >>= 8 {
+		size++
+	}
+
+	return size
+}
+
+// headerLength returns the size of the RLP header (in bytes)
+// for an item with the given content size
+func headerLength(size int) int {
+	if size <= 55 {
+		return 1
+	}
+
+	return 1 + intSize(uint64(size))
+}
+
+// EncodeValue encodes the given decoded value (tree) back to RLP.
+// For canonical input, EncodeValue(DecodeBytes(input)) == input.
+// RawValues are written in their original encoding.
+//
+// NOTE: EncodeValue panics if the value (or any nested value)
+// is of an unknown type
+func EncodeValue(input Value) []byte {
+	return AppendValue(nil, input)
+}
+
+// AppendValue appends the RLP encoding of the given decoded value (tree) to dst,
+// and returns the extended byte array
+func AppendValue(dst []byte, input Value) []byte {
+	switch input.GetType() {
+	case Bytes:
+		value, _ := input.GetValue().([]byte)
+
+		return AppendBytes(dst, value)
+	case List:
+		values, _ := input.GetValue().([]Value)
+
+		return AppendList(dst, func(dst []byte) []byte {
+			for _, value := range values {
+				dst = AppendValue(dst, value)
+			}
+
+			return dst
+		})
+	case Raw:
+		// The original encoding is kept as-is
+		value, _ := input.GetValue().([]byte)
+
+		return append(dst, value...)
+	default:
+		panic(fmt.Sprintf("unable to encode value of type %s", input.GetType()))
+	}
+}
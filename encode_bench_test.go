@@ -1,6 +1,9 @@
 package ethrlp
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+)
 
 func benchmarkStringCommon(b *testing.B, value string) {
 	b.Helper()
@@ -152,3 +155,83 @@ func BenchmarkEncode_Array_Nested_Long(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkEncodeSlice_Medium(b *testing.B) {
+	items := []string{
+		"aaa", "bbb", "ccc", "ddd", "eee",
+		"fff", "ggg", "hhh", "iii", "jjj",
+		"kkk", "lll", "mmm", "nnn", "ooo",
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = EncodeSlice(items, AppendString)
+	}
+}
+
+func BenchmarkEncoderBuffer_Array_Nested_Long(b *testing.B) {
+	buf := NewEncoderBuffer(nil)
+
+	for i := 0; i < b.N; i++ {
+		buf.Reset(nil)
+
+		outer := buf.List()
+
+		for j := 0; j < 4; j++ {
+			nested := buf.List()
+
+			buf.WriteString("asdf")
+			buf.WriteString("qwer")
+			buf.WriteString("zxcv")
+
+			buf.ListEnd(nested)
+		}
+
+		buf.ListEnd(outer)
+
+		_ = buf.ToBytes()
+	}
+}
+
+func BenchmarkEncode_Uint_Small(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = EncodeUint(9)
+	}
+}
+
+func BenchmarkEncode_Uint_Medium(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = EncodeUint(21000)
+	}
+}
+
+func BenchmarkEncode_Uint_Large(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = EncodeUint(0xFFFFFFFFFFFFFFFF)
+	}
+}
+
+func BenchmarkEncode_Int(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = EncodeInt(20000000000)
+	}
+}
+
+func BenchmarkEncode_BigInt(b *testing.B) {
+	value := big.NewInt(0).SetUint64(1000000000000000000)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = EncodeBigInt(value)
+	}
+}
+
+func BenchmarkAppend_Uint(b *testing.B) {
+	buf := make([]byte, 0, 9)
+
+	for i := 0; i < b.N; i++ {
+		_ = AppendUint64(buf[:0], 21000)
+	}
+}
@@ -78,3 +78,45 @@ func ExampleEncodeArray() {
 	// CC8568656C6C6F85776F726C64
 	// F83C836161618362626283636363836464648365656583666666836767678368686883696969836A6A6A836B6B6B836C6C6C836D6D6D836E6E6E836F6F6F
 }
+
+func ExampleAppendList() {
+	buf := make([]byte, 0, 64)
+
+	buf = AppendList(buf, func(dst []byte) []byte {
+		dst = AppendString(dst, "hello")
+
+		return AppendString(dst, "world")
+	})
+
+	fmt.Printf("%X\n", buf)
+
+	// Output:
+	// CC8568656C6C6F85776F726C64
+}
+
+func ExampleEncodeValue() {
+	value, err := DecodeBytes([]byte{0xC8, 0x83, 0x63, 0x61, 0x74, 0x83, 0x64, 0x6F, 0x67})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%X\n", EncodeValue(value))
+
+	// Output:
+	// C88363617483646F67
+}
+
+func ExampleEncodeSlice() {
+	fmt.Printf("%X\n", EncodeSlice([]string{"hello", "world"}, AppendString))
+
+	// Output:
+	// CC8568656C6C6F85776F726C64
+}
+
+func ExampleHashOf() {
+	// Hash of an empty list (the Ethereum empty uncle hash)
+	fmt.Printf("%x\n", HashOf(EncodeArray(nil)))
+
+	// Output:
+	// 1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347
+}
@@ -1017,3 +1017,297 @@ func TestEncode_EthereumTests(t *testing.T) {
 		)
 	})
 }
+
+func TestAppend_Items(t *testing.T) {
+	t.Parallel()
+
+	largeNumber, ok := big.NewInt(0).SetString(
+		"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+		10,
+	)
+	require.True(t, ok)
+
+	longString := "Lorem ipsum dolor sit amet, consectetur adipisicing elit"
+
+	testTable := []struct {
+		name           string
+		appendOutput   []byte
+		expectedOutput []byte
+	}{
+		{
+			"bool true",
+			AppendBool(nil, true),
+			EncodeBool(true),
+		},
+		{
+			"bool false",
+			AppendBool(nil, false),
+			EncodeBool(false),
+		},
+		{
+			"byte in [0x00, 0x7f] range",
+			AppendByte(nil, 0x7f),
+			EncodeByte(0x7f),
+		},
+		{
+			"byte in [0x80, 0xff] range",
+			AppendByte(nil, 0x80),
+			EncodeByte(0x80),
+		},
+		{
+			"empty bytes",
+			AppendBytes(nil, []byte{}),
+			EncodeBytes([]byte{}),
+		},
+		{
+			"single byte bytes",
+			AppendBytes(nil, []byte{0x80}),
+			EncodeBytes([]byte{0x80}),
+		},
+		{
+			"long bytes",
+			AppendBytes(nil, []byte(longString)),
+			EncodeBytes([]byte(longString)),
+		},
+		{
+			"short string",
+			AppendString(nil, "dog"),
+			EncodeString("dog"),
+		},
+		{
+			"long string",
+			AppendString(nil, longString),
+			EncodeString(longString),
+		},
+		{
+			"uint 0",
+			AppendUint64(nil, 0),
+			EncodeUint(0),
+		},
+		{
+			"uint 127",
+			AppendUint64(nil, 127),
+			EncodeUint(127),
+		},
+		{
+			"uint 1024",
+			AppendUint64(nil, 1024),
+			EncodeUint(1024),
+		},
+		{
+			"max uint64",
+			AppendUint64(nil, math.MaxUint64),
+			EncodeUint(math.MaxUint64),
+		},
+		{
+			"big int 0",
+			AppendBigInt(nil, big.NewInt(0)),
+			EncodeBigInt(big.NewInt(0)),
+		},
+		{
+			"big int over 64 bits",
+			AppendBigInt(nil, largeNumber),
+			EncodeBigInt(largeNumber),
+		},
+		{
+			"short list header",
+			AppendListHeader(nil, 55),
+			hexToBytes(t, "f7"),
+		},
+		{
+			"long list header",
+			AppendListHeader(nil, 1024),
+			hexToBytes(t, "f90400"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, testCase.appendOutput)
+		})
+	}
+}
+
+func TestAppend_ExistingBuffer(t *testing.T) {
+	t.Parallel()
+
+	prefix := []byte{0x01, 0x02}
+
+	output := AppendString(prefix, "dog")
+
+	assert.Equal(t, append([]byte{0x01, 0x02}, EncodeString("dog")...), output)
+}
+
+func TestAppend_List(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		elements [][]byte
+	}{
+		{
+			"empty list",
+			[][]byte{},
+		},
+		{
+			"short list",
+			[][]byte{
+				EncodeString("cat"),
+				EncodeString("dog"),
+			},
+		},
+		{
+			"short list max",
+			[][]byte{
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+			},
+		},
+		{
+			"long list",
+			[][]byte{
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			prefix := []byte{0x01}
+
+			output := AppendList(prefix, func(dst []byte) []byte {
+				for _, element := range testCase.elements {
+					dst = append(dst, element...)
+				}
+
+				return dst
+			})
+
+			assert.Equal(t, append([]byte{0x01}, EncodeArray(testCase.elements)...), output)
+		})
+	}
+}
+
+func TestAppend_NoAllocations(t *testing.T) {
+	// AllocsPerRun cannot be used in parallel tests
+	buf := make([]byte, 0, 1024)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = AppendList(buf[:0], func(dst []byte) []byte {
+			dst = AppendUint64(dst, 9)
+			dst = AppendUint64(dst, 20000000000)
+			dst = AppendUint64(dst, 21000)
+			dst = AppendString(dst, "Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+
+			return AppendBool(dst, true)
+		})
+	})
+
+	assert.Zero(t, allocs)
+}
+
+func TestEncode_Uint_MatchesBigInt(t *testing.T) {
+	t.Parallel()
+
+	// Check every byte length, and the boundaries around it
+	for shift := uint(0); shift < 64; shift++ {
+		for _, value := range []uint64{
+			(1 << shift) - 1,
+			1 << shift,
+			(1 << shift) + 1,
+		} {
+			expected := EncodeBytes(big.NewInt(0).SetUint64(value).Bytes())
+
+			assert.Equal(t, expected, EncodeUint(value), "value %d", value)
+			assert.Equal(t, expected, EncodeBigInt(big.NewInt(0).SetUint64(value)), "value %d", value)
+
+			if value <= math.MaxInt64 {
+				assert.Equal(t, expected, EncodeInt(int64(value)), "value %d", value)
+			}
+		}
+	}
+}
+
+func TestEncode_NegativeIntegers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("checked int", func(t *testing.T) {
+		t.Parallel()
+
+		output, err := EncodeIntE(1024)
+		require.NoError(t, err)
+
+		assert.Equal(t, EncodeInt(1024), output)
+
+		_, err = EncodeIntE(-5)
+		assert.ErrorIs(t, err, ErrNegativeBigInt)
+
+		_, err = EncodeIntE(math.MinInt64)
+		assert.ErrorIs(t, err, ErrNegativeBigInt)
+	})
+
+	t.Run("checked big int", func(t *testing.T) {
+		t.Parallel()
+
+		output, err := EncodeBigIntE(big.NewInt(1024))
+		require.NoError(t, err)
+
+		assert.Equal(t, EncodeInt(1024), output)
+
+		_, err = EncodeBigIntE(big.NewInt(-5))
+		assert.ErrorIs(t, err, ErrNegativeBigInt)
+
+		// A nil input is rejected as a negative one, keeping the distinction
+		_, err = EncodeBigIntE(nil)
+		assert.ErrorIs(t, err, ErrNegativeBigInt)
+		assert.ErrorIs(t, err, ErrNilBigInt)
+	})
+
+	t.Run("unchecked functions panic", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			_ = EncodeInt(-5)
+		})
+
+		assert.Panics(t, func() {
+			_ = EncodeBigInt(big.NewInt(-5))
+		})
+
+		assert.Panics(t, func() {
+			_ = EncodeBigInt(nil)
+		})
+
+		assert.Panics(t, func() {
+			_ = AppendBigInt(nil, big.NewInt(-5))
+		})
+
+		assert.Panics(t, func() {
+			NewEncoderBuffer(nil).WriteBigInt(big.NewInt(-5))
+		})
+	})
+}
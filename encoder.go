@@ -0,0 +1,199 @@
+package ethrlp
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+var ErrNoWriter = errors.New("encoder buffer has no writer")
+
+// listHead is the deferred header of a list written to the EncoderBuffer
+type listHead struct {
+	offset int // position of the list content in the output (excluding list headers)
+	size   int // size of the list content (including nested list headers)
+}
+
+// EncoderBuffer is an RLP encoder that writes items into a single growing buffer.
+// Unlike EncodeArray, nested lists are not encoded (and copied) separately:
+// list header positions are recorded when the list is started,
+// and the headers are inserted only once the final output is produced.
+//
+// Lists are written by calling List, writing the list elements,
+// and finally calling ListEnd with the index returned by List
+type EncoderBuffer struct {
+	w io.Writer
+
+	str    []byte     // encoded data, excluding list headers
+	lheads []listHead // deferred list headers, in order of appearance
+	lhsize int        // combined size of the (finished) list headers
+}
+
+// NewEncoderBuffer creates a new encoder buffer that flushes into the given writer.
+// The writer can be nil, if the output is only ever fetched with ToBytes or AppendToBytes
+func NewEncoderBuffer(w io.Writer) *EncoderBuffer {
+	return &EncoderBuffer{
+		w: w,
+	}
+}
+
+// Reset discards the buffered data, and sets the writer used for flushing.
+// The underlying memory is kept for reuse
+func (b *EncoderBuffer) Reset(w io.Writer) {
+	b.w = w
+	b.str = b.str[:0]
+	b.lheads = b.lheads[:0]
+	b.lhsize = 0
+}
+
+// Size returns the size (in bytes) of the encoded output.
+// Only list headers of finished lists are accounted for
+func (b *EncoderBuffer) Size() int {
+	return len(b.str) + b.lhsize
+}
+
+// WriteBytes encodes the given byte array as an RLP string
+func (b *EncoderBuffer) WriteBytes(input []byte) {
+	b.str = AppendBytes(b.str, input)
+}
+
+// WriteString encodes the given string as an RLP string
+func (b *EncoderBuffer) WriteString(input string) {
+	b.str = AppendString(b.str, input)
+}
+
+// WriteBool encodes the given bool (as 0x01 or 0x80)
+func (b *EncoderBuffer) WriteBool(input bool) {
+	b.str = AppendBool(b.str, input)
+}
+
+// WriteUint64 encodes the given unsigned integer,
+// as its minimal big-endian representation
+func (b *EncoderBuffer) WriteUint64(input uint64) {
+	b.str = AppendUint64(b.str, input)
+}
+
+// WriteBigInt encodes the given big integer,
+// as its minimal big-endian representation.
+// WriteBigInt panics if the input is negative or nil
+func (b *EncoderBuffer) WriteBigInt(input *big.Int) {
+	b.str = AppendBigInt(b.str, input)
+}
+
+// WriteRaw writes the given bytes to the output as-is.
+// The bytes are expected to be a valid RLP encoding
+func (b *EncoderBuffer) WriteRaw(input []byte) {
+	b.str = append(b.str, input...)
+}
+
+// WriteEncoder encodes the given encoder, by appending
+// its RLP encoding directly to the buffer
+func (b *EncoderBuffer) WriteEncoder(e RLPEncoder) {
+	b.str = e.AppendRLP(b.str)
+}
+
+// List starts a new list, and returns its index.
+// All subsequent writes are elements of the list,
+// until ListEnd is called with the returned index
+func (b *EncoderBuffer) List() int {
+	// The size temporarily holds the combined list header size
+	// at the moment the list was started, so the nested list headers
+	// can be accounted for in the list size once the list is finished
+	b.lheads = append(b.lheads, listHead{
+		offset: len(b.str),
+		size:   b.lhsize,
+	})
+
+	return len(b.lheads) - 1
+}
+
+// ListEnd finishes the list with the given index (returned by List)
+func (b *EncoderBuffer) ListEnd(index int) {
+	lh := &b.lheads[index]
+
+	lh.size = b.Size() - lh.offset - lh.size
+	b.lhsize += headerLength(lh.size)
+}
+
+// ToBytes returns the encoded output in a newly allocated byte array.
+// All started lists need to be finished before the output is produced
+func (b *EncoderBuffer) ToBytes() []byte {
+	return b.AppendToBytes(make([]byte, 0, b.Size()))
+}
+
+// AppendToBytes appends the encoded output to the given byte array
+func (b *EncoderBuffer) AppendToBytes(dst []byte) []byte {
+	strpos := 0
+
+	for _, lh := range b.lheads {
+		// Write the data leading up to the list,
+		// followed by the list header
+		dst = append(dst, b.str[strpos:lh.offset]...)
+		dst = appendHeader(dst, 0xc0, lh.size)
+
+		strpos = lh.offset
+	}
+
+	// Write the data that follows the last list header
+	return append(dst, b.str[strpos:]...)
+}
+
+// Flush writes the encoded output to the writer, and resets the buffer
+func (b *EncoderBuffer) Flush() error {
+	if b.w == nil {
+		return ErrNoWriter
+	}
+
+	if err := b.writeTo(b.w); err != nil {
+		return err
+	}
+
+	b.Reset(b.w)
+
+	return nil
+}
+
+// Hash returns the Keccak-256 hash of the encoded output.
+// The output is streamed into the hasher, without being materialized,
+// and the buffer is kept as-is.
+// All started lists need to be finished before the hash is computed
+func (b *EncoderBuffer) Hash() [32]byte {
+	var (
+		k      Keccak256
+		digest [32]byte
+	)
+
+	//nolint:errcheck // Keccak256 writes cannot fail
+	_ = b.writeTo(&k)
+	k.sum(digest[:0])
+
+	return digest
+}
+
+// writeTo writes the encoded output to the given writer,
+// inserting the list headers in between the buffered data
+func (b *EncoderBuffer) writeTo(w io.Writer) error {
+	strpos := 0
+
+	// The header scratch space fits the largest header
+	var header [maxHeaderSize]byte
+
+	for _, lh := range b.lheads {
+		// Write the data leading up to the list,
+		// followed by the list header
+		if _, err := w.Write(b.str[strpos:lh.offset]); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(appendHeader(header[:0], 0xc0, lh.size)); err != nil {
+			return err
+		}
+
+		strpos = lh.offset
+	}
+
+	// Write the data that follows the last list header
+	_, err := w.Write(b.str[strpos:])
+
+	return err
+}
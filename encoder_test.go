@@ -0,0 +1,291 @@
+package ethrlp
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errWriter is an io.Writer that always fails
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write(_ []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestEncoderBuffer_Items(t *testing.T) {
+	t.Parallel()
+
+	largeNumber, ok := big.NewInt(0).SetString(
+		"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+		10,
+	)
+	require.True(t, ok)
+
+	testTable := []struct {
+		write          func(b *EncoderBuffer)
+		name           string
+		expectedOutput []byte
+	}{
+		{
+			func(b *EncoderBuffer) {
+				b.WriteBytes([]byte{})
+			},
+			"empty bytes",
+			EncodeBytes([]byte{}),
+		},
+		{
+			func(b *EncoderBuffer) {
+				b.WriteBytes([]byte{0x7f})
+			},
+			"single byte",
+			EncodeByte(0x7f),
+		},
+		{
+			func(b *EncoderBuffer) {
+				b.WriteBytes([]byte{0x80})
+			},
+			"single byte above 0x7f",
+			EncodeByte(0x80),
+		},
+		{
+			func(b *EncoderBuffer) {
+				b.WriteString("Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+			},
+			"long string",
+			EncodeString("Lorem ipsum dolor sit amet, consectetur adipisicing elit"),
+		},
+		{
+			func(b *EncoderBuffer) {
+				b.WriteBool(true)
+				b.WriteBool(false)
+			},
+			"bools",
+			append(EncodeBool(true), EncodeBool(false)...),
+		},
+		{
+			func(b *EncoderBuffer) {
+				b.WriteUint64(0)
+				b.WriteUint64(0x7f)
+				b.WriteUint64(0x80)
+				b.WriteUint64(0xFFFFFFFFFFFFFFFF)
+			},
+			"uints",
+			bytes.Join([][]byte{
+				EncodeUint(0),
+				EncodeUint(0x7f),
+				EncodeUint(0x80),
+				EncodeUint(0xFFFFFFFFFFFFFFFF),
+			}, nil),
+		},
+		{
+			func(b *EncoderBuffer) {
+				b.WriteBigInt(big.NewInt(1024))
+				b.WriteBigInt(largeNumber)
+			},
+			"big ints",
+			append(EncodeBigInt(big.NewInt(1024)), EncodeBigInt(largeNumber)...),
+		},
+		{
+			func(b *EncoderBuffer) {
+				b.WriteRaw(EncodeString("dog"))
+			},
+			"raw",
+			EncodeString("dog"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := NewEncoderBuffer(nil)
+			testCase.write(b)
+
+			assert.Equal(t, len(testCase.expectedOutput), b.Size())
+			assert.Equal(t, testCase.expectedOutput, b.ToBytes())
+		})
+	}
+}
+
+func TestEncoderBuffer_Lists(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty list", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewEncoderBuffer(nil)
+		b.ListEnd(b.List())
+
+		assert.Equal(t, EmptyArray, b.ToBytes())
+	})
+
+	t.Run("listsoflists2", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewEncoderBuffer(nil)
+
+		outer := b.List()
+		b.ListEnd(b.List())
+
+		second := b.List()
+		b.ListEnd(b.List())
+		b.ListEnd(second)
+
+		third := b.List()
+		b.ListEnd(b.List())
+
+		thirdNested := b.List()
+		b.ListEnd(b.List())
+		b.ListEnd(thirdNested)
+		b.ListEnd(third)
+		b.ListEnd(outer)
+
+		assert.Equal(t, hexToBytes(t, "c7c0c1c0c3c0c1c0"), b.ToBytes())
+	})
+
+	t.Run("long nested lists", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			elements = make([][]byte, 0, 20)
+			b        = NewEncoderBuffer(nil)
+		)
+
+		outer := b.List()
+
+		for i := 0; i < 20; i++ {
+			nested := b.List()
+
+			b.WriteString("asdf")
+			b.WriteString("qwer")
+			b.WriteString("zxcv")
+
+			b.ListEnd(nested)
+
+			elements = append(elements, EncodeArray([][]byte{
+				EncodeString("asdf"),
+				EncodeString("qwer"),
+				EncodeString("zxcv"),
+			}))
+		}
+
+		b.ListEnd(outer)
+
+		expected := EncodeArray(elements)
+
+		assert.Equal(t, len(expected), b.Size())
+		assert.Equal(t, expected, b.ToBytes())
+	})
+
+	t.Run("EIP155 transaction", func(t *testing.T) {
+		t.Parallel()
+
+		r, _ := big.NewInt(0).SetString("28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276", 16)
+		s, _ := big.NewInt(0).SetString("67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", 16)
+
+		b := NewEncoderBuffer(nil)
+
+		tx := b.List()
+		b.WriteUint64(9)
+		b.WriteUint64(20000000000)
+		b.WriteUint64(21000)
+		b.WriteBytes(hexToBytes(t, "3535353535353535353535353535353535353535"))
+		b.WriteUint64(1000000000000000000)
+		b.WriteBytes([]byte{})
+		b.WriteUint64(37)
+		b.WriteBigInt(r)
+		b.WriteBigInt(s)
+		b.ListEnd(tx)
+
+		assert.Equal(
+			t,
+			hexToBytes(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+			b.ToBytes(),
+		)
+	})
+}
+
+func TestEncoderBuffer_Flush(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid writer", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			out bytes.Buffer
+			b   = NewEncoderBuffer(&out)
+		)
+
+		list := b.List()
+		b.WriteString("cat")
+		b.WriteString("dog")
+		b.ListEnd(list)
+
+		expected := b.AppendToBytes([]byte{0x01})
+
+		require.NoError(t, b.Flush())
+
+		assert.Equal(t, expected[1:], out.Bytes())
+		assert.Zero(t, b.Size())
+	})
+
+	t.Run("no writer", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewEncoderBuffer(nil)
+		b.WriteString("dog")
+
+		assert.ErrorIs(t, b.Flush(), ErrNoWriter)
+	})
+
+	t.Run("writer error", func(t *testing.T) {
+		t.Parallel()
+
+		writeErr := errors.New("write error")
+
+		b := NewEncoderBuffer(errWriter{err: writeErr})
+		b.WriteString("dog")
+
+		assert.ErrorIs(t, b.Flush(), writeErr)
+	})
+}
+
+func TestEncoderBuffer_Hash(t *testing.T) {
+	t.Parallel()
+
+	b := NewEncoderBuffer(nil)
+
+	outer := b.List()
+	b.WriteString("cat")
+
+	inner := b.List()
+	b.WriteBytes(bytes.Repeat([]byte{0x01}, 300))
+	b.ListEnd(inner)
+
+	b.WriteUint64(1024)
+	b.ListEnd(outer)
+
+	// Make sure the streamed hash matches the hash of the materialized output
+	assert.Equal(t, HashOf(b.ToBytes()), b.Hash())
+
+	// Make sure the buffer is kept as-is
+	assert.Equal(t, len(b.ToBytes()), b.Size())
+
+	// Make sure the hasher can be used as the flush writer
+	k := NewKeccak256()
+
+	b.Reset(k)
+	b.WriteString("dog")
+
+	expected := b.Hash()
+
+	require.NoError(t, b.Flush())
+	assert.Equal(t, expected[:], k.Sum(nil))
+}
@@ -0,0 +1,104 @@
+package ethrlp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addSeeds adds the canonical test vectors to the fuzz seed corpus,
+// along with malformed variants of them
+func addSeeds(f *testing.F) {
+	f.Helper()
+
+	for _, vector := range canonicalVectors(f) {
+		f.Add(vector)
+
+		// Truncated input
+		f.Add(vector[:len(vector)/2])
+
+		// Trailing bytes
+		f.Add(append(bytes.Clone(vector), 0x00))
+	}
+
+	// Empty input, and length prefixes that overflow
+	f.Add([]byte{})
+	f.Add(hexToBytes(f, "bfffffffffffffffff"))
+	f.Add(hexToBytes(f, "ffffffffffffffffff"))
+	f.Add(hexToBytes(f, "f9000100"))
+}
+
+func FuzzDecodeBytes(f *testing.F) {
+	addSeeds(f)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		// Make sure none of the decoders panic
+		value, err := DecodeBytes(input)
+		if err != nil {
+			var decodeErr *DecodeError
+
+			require.ErrorAs(t, err, &decodeErr)
+			assert.LessOrEqual(t, decodeErr.Offset, len(input))
+		}
+
+		strictValue, strictErr := DecodeStrict(input)
+		if strictErr == nil {
+			// Strict decoding is a subset of the lenient decoding
+			require.NoError(t, err)
+			assert.Equal(t, value, strictValue)
+		}
+
+		_, _ = DefaultDecodeOptions.Decode(input)
+		_, _, _ = SplitValue(input)
+		_, _ = CountValues(input)
+
+		if raw, err := DecodeRaw(input); err == nil {
+			_, _ = raw.Elements()
+			_, _ = Get(raw, 0, 0)
+		}
+
+		// Make sure the stream agrees with the strict decoder
+		s := NewStream(bytes.NewReader(input), 0)
+
+		streamRaw, streamErr := s.Raw()
+		if streamErr == nil {
+			_, streamErr = s.Raw()
+			if errors.Is(streamErr, io.EOF) {
+				streamErr = nil
+			}
+		}
+
+		if strictErr == nil {
+			require.NoError(t, streamErr)
+			assert.Equal(t, input, streamRaw)
+		}
+	})
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	addSeeds(f)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		value, err := DecodeBytes(input)
+		if err != nil {
+			return
+		}
+
+		// The re-encoded value is always canonical
+		encoded := EncodeValue(value)
+
+		decoded, err := DecodeStrict(encoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, value, decoded)
+
+		// Canonical input is re-encoded as-is
+		if _, err := DecodeStrict(input); err == nil {
+			assert.Equal(t, input, encoded)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package ethrlp
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// keccakRate is the Keccak-256 sponge rate (in bytes), 1600 - 2*256 bits
+	keccakRate = 136
+
+	// keccakSize is the size of the Keccak-256 digest (in bytes)
+	keccakSize = 32
+
+	// keccakPadding is the (legacy, pre-SHA3) Keccak domain padding byte
+	keccakPadding = 0x01
+)
+
+// keccakRoundConstants are the iota step constants of the 24 Keccak-f[1600] rounds
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var (
+	// keccakRotations are the rho step rotation offsets, in pi step lane order
+	keccakRotations = [24]int{
+		1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44,
+	}
+
+	// keccakLanes is the pi step lane order
+	keccakLanes = [24]int{
+		10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1,
+	}
+)
+
+var _ hash.Hash = (*Keccak256)(nil)
+
+// Keccak256 is a (legacy) Keccak-256 hasher, as used by Ethereum.
+// It differs from the standardized SHA3-256 only in its padding.
+//
+// Keccak256 implements hash.Hash (and io.Writer), so it can be used
+// as the writer of an EncoderBuffer, to hash the encoded output while it is flushed
+type Keccak256 struct {
+	state [25]uint64       // sponge state
+	buf   [keccakRate]byte // pending (not yet absorbed) input
+	n     int              // number of pending input bytes
+}
+
+// NewKeccak256 creates a new Keccak-256 hasher
+func NewKeccak256() *Keccak256 {
+	return &Keccak256{}
+}
+
+// HashOf returns the Keccak-256 hash of the given (encoded) bytes
+func HashOf(encoded []byte) [32]byte {
+	var (
+		k      Keccak256
+		digest [32]byte
+	)
+
+	//nolint:errcheck // Keccak256 writes cannot fail
+	_, _ = k.Write(encoded)
+	k.sum(digest[:0])
+
+	return digest
+}
+
+// Write absorbs the given bytes into the hash state. It never returns an error
+func (k *Keccak256) Write(p []byte) (int, error) {
+	written := len(p)
+
+	// Fill up the pending input block first
+	if k.n > 0 {
+		copied := copy(k.buf[k.n:], p)
+
+		k.n += copied
+		p = p[copied:]
+
+		if k.n < keccakRate {
+			return written, nil
+		}
+
+		k.absorb(k.buf[:])
+		k.n = 0
+	}
+
+	// Absorb the full blocks directly from the input
+	for len(p) >= keccakRate {
+		k.absorb(p[:keccakRate])
+		p = p[keccakRate:]
+	}
+
+	k.n = copy(k.buf[:], p)
+
+	return written, nil
+}
+
+// Sum appends the current hash to b, and returns the extended byte array.
+// It does not change the hash state
+func (k *Keccak256) Sum(b []byte) []byte {
+	// Work on a copy, so the writes can continue
+	d := *k
+
+	return d.sum(b)
+}
+
+// Reset resets the hasher to its initial state
+func (k *Keccak256) Reset() {
+	*k = Keccak256{}
+}
+
+// Size returns the size of the Keccak-256 digest (32B)
+func (k *Keccak256) Size() int {
+	return keccakSize
+}
+
+// BlockSize returns the sponge rate of Keccak-256 (136B)
+func (k *Keccak256) BlockSize() int {
+	return keccakRate
+}
+
+// sum pads and absorbs the pending input, and appends the digest to b.
+// The hash state is consumed
+func (k *Keccak256) sum(b []byte) []byte {
+	clear(k.buf[k.n:])
+
+	k.buf[k.n] = keccakPadding
+	k.buf[keccakRate-1] |= 0x80
+
+	k.absorb(k.buf[:])
+
+	for lane := 0; lane < keccakSize/8; lane++ {
+		b = binary.LittleEndian.AppendUint64(b, k.state[lane])
+	}
+
+	return b
+}
+
+// absorb XORs the given (rate-sized) block into the state, and permutes it
+func (k *Keccak256) absorb(block []byte) {
+	for lane := 0; lane < keccakRate/8; lane++ {
+		k.state[lane] ^= binary.LittleEndian.Uint64(block[lane*8:])
+	}
+
+	keccakF1600(&k.state)
+}
+
+// keccakF1600 applies the Keccak-f[1600] permutation to the given state
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+
+	for _, rc := range keccakRoundConstants {
+		// Theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+
+		// Rho and pi
+		current := a[1]
+
+		for i, lane := range keccakLanes {
+			current, a[lane] = a[lane], bits.RotateLeft64(current, keccakRotations[i])
+		}
+
+		// Chi
+		for y := 0; y < 25; y += 5 {
+			copy(c[:], a[y:y+5])
+
+			for x := 0; x < 5; x++ {
+				a[y+x] = c[x] ^ (^c[(x+1)%5] & c[(x+2)%5])
+			}
+		}
+
+		// Iota
+		a[0] ^= rc
+	}
+}
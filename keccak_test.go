@@ -0,0 +1,104 @@
+package ethrlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeccak256_Vectors(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{
+			"empty input",
+			[]byte{},
+			"c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		},
+		{
+			"short string",
+			[]byte("abc"),
+			"4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		},
+		{
+			"empty string (empty trie root)",
+			EncodeBytes(nil),
+			"56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		},
+		{
+			"empty list (empty uncle hash)",
+			EncodeArray(nil),
+			"1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			digest := HashOf(testCase.input)
+
+			assert.Equal(t, hexToBytes(t, testCase.expected), digest[:])
+		})
+	}
+}
+
+func TestKeccak256_Write(t *testing.T) {
+	t.Parallel()
+
+	// Sizes around the rate boundaries
+	sizes := []int{0, 1, 135, 136, 137, 271, 272, 273, 1000}
+
+	for _, size := range sizes {
+		input := make([]byte, size)
+		for i := range input {
+			input[i] = byte(i * 7)
+		}
+
+		expected := HashOf(input)
+
+		// Make sure chunked writes match a single write
+		for _, chunk := range []int{1, 13, 136, 200} {
+			k := NewKeccak256()
+
+			for offset := 0; offset < size; offset += chunk {
+				n, err := k.Write(input[offset:min(offset+chunk, size)])
+
+				assert.NoError(t, err)
+				assert.Equal(t, min(chunk, size-offset), n)
+			}
+
+			assert.Equal(t, expected[:], k.Sum(nil), "size %d, chunk %d", size, chunk)
+		}
+	}
+}
+
+func TestKeccak256_Sum(t *testing.T) {
+	t.Parallel()
+
+	k := NewKeccak256()
+
+	assert.Equal(t, 32, k.Size())
+	assert.Equal(t, 136, k.BlockSize())
+
+	_, _ = k.Write([]byte("a"))
+
+	// Make sure Sum does not change the hash state
+	assert.Equal(t, []byte{0xff}, k.Sum([]byte{0xff})[:1])
+	assert.Equal(t, k.Sum(nil), k.Sum(nil))
+
+	_, _ = k.Write([]byte("bc"))
+
+	abc := HashOf([]byte("abc"))
+	assert.Equal(t, abc[:], k.Sum(nil))
+
+	// Make sure Reset clears the hash state
+	k.Reset()
+
+	_, _ = k.Write([]byte("abc"))
+	assert.Equal(t, abc[:], k.Sum(nil))
+}
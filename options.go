@@ -0,0 +1,62 @@
+package ethrlp
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrMaxDepth        = errors.New("maximum list nesting depth exceeded")
+	ErrMaxListElements = errors.New("maximum list element count exceeded")
+	ErrMaxInputSize    = errors.New("maximum input size exceeded")
+)
+
+// DecodeOptions configures the decoding of RLP input, bounding
+// the work (and memory) an untrusted input can force on the decoder.
+// A zero limit means the limit is not enforced
+type DecodeOptions struct {
+	MaxDepth        int  // maximum list nesting depth (a top-level list is at depth 1)
+	MaxListElements int  // maximum number of elements in a single list
+	MaxInputSize    int  // maximum size of the input (in bytes)
+	Strict          bool // flag indicating if canonical encoding is enforced (as with DecodeStrict)
+}
+
+// DefaultDecodeOptions are the decoding options suitable for network-facing code
+var DefaultDecodeOptions = DecodeOptions{
+	MaxDepth:        64,
+	MaxListElements: 1 << 16,
+	MaxInputSize:    10 * 1024 * 1024, // 10 MB
+	Strict:          true,
+}
+
+// Decode attempts to decode the given bytes from RLP, within the limits of the options.
+// The input needs to contain exactly one top-level RLP item
+func (o DecodeOptions) Decode(input []byte) (Value, error) {
+	value, rest, err := o.SplitValue(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) != 0 {
+		return nil, &DecodeError{
+			Err:    fmt.Errorf("%w: %dB", ErrTrailingBytes, len(rest)),
+			Offset: len(input) - len(rest),
+			Kind:   itemKind(rest),
+		}
+	}
+
+	return value, nil
+}
+
+// SplitValue decodes the first RLP item from the given bytes, within the limits
+// of the options, and returns it along with the remaining (unconsumed) input
+func (o DecodeOptions) SplitValue(input []byte) (Value, []byte, error) {
+	if o.MaxInputSize > 0 && len(input) > o.MaxInputSize {
+		return nil, nil, &DecodeError{
+			Err:  fmt.Errorf("%w: %dB, limit %dB", ErrMaxInputSize, len(input), o.MaxInputSize),
+			Kind: itemKind(input),
+		}
+	}
+
+	return decodeBytes(input, o)
+}
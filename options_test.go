@@ -0,0 +1,157 @@
+package ethrlp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nestedLists returns the encoding of the given number of nested (empty) lists
+func nestedLists(depth int) []byte {
+	encoding := EmptyArray
+
+	for i := 1; i < depth; i++ {
+		encoding = EncodeArray([][]byte{encoding})
+	}
+
+	return encoding
+}
+
+func TestDecodeOptions_Limits(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		expectedErr error
+		name        string
+		input       []byte
+		opts        DecodeOptions
+	}{
+		{
+			nil,
+			"depth at the limit",
+			nestedLists(3),
+			DecodeOptions{MaxDepth: 3},
+		},
+		{
+			ErrMaxDepth,
+			"depth over the limit",
+			nestedLists(4),
+			DecodeOptions{MaxDepth: 3},
+		},
+		{
+			nil,
+			"bytes at depth zero",
+			hexToBytes(t, "83646f67"),
+			DecodeOptions{MaxDepth: 0},
+		},
+		{
+			nil,
+			"element count at the limit",
+			EncodeSlice([]uint64{1, 2, 3}, AppendUint64),
+			DecodeOptions{MaxListElements: 3},
+		},
+		{
+			ErrMaxListElements,
+			"element count over the limit",
+			EncodeSlice([]uint64{1, 2, 3, 4}, AppendUint64),
+			DecodeOptions{MaxListElements: 3},
+		},
+		{
+			ErrMaxListElements,
+			"nested element count over the limit",
+			EncodeArray([][]byte{EncodeSlice([]uint64{1, 2, 3, 4}, AppendUint64)}),
+			DecodeOptions{MaxListElements: 3},
+		},
+		{
+			nil,
+			"input size at the limit",
+			hexToBytes(t, "83646f67"),
+			DecodeOptions{MaxInputSize: 4},
+		},
+		{
+			ErrMaxInputSize,
+			"input size over the limit",
+			hexToBytes(t, "83646f67"),
+			DecodeOptions{MaxInputSize: 3},
+		},
+		{
+			ErrCanonSize,
+			"strict decoding",
+			hexToBytes(t, "c3018100"),
+			DecodeOptions{Strict: true},
+		},
+		{
+			nil,
+			"no limits",
+			nestedLists(1000),
+			DecodeOptions{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testCase.opts.Decode(testCase.input)
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDecodeOptions_Default(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid transaction", func(t *testing.T) {
+		t.Parallel()
+
+		input := hexToBytes(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+
+		value, err := DefaultDecodeOptions.Decode(input)
+		require.NoError(t, err)
+
+		expected, err := DecodeStrict(input)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, value)
+	})
+
+	t.Run("deeply nested input", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DefaultDecodeOptions.Decode(nestedLists(DefaultDecodeOptions.MaxDepth + 1))
+		assert.ErrorIs(t, err, ErrMaxDepth)
+	})
+
+	t.Run("large list", func(t *testing.T) {
+		t.Parallel()
+
+		input := EncodeSlice(
+			bytes.Repeat([]byte{0x01}, DefaultDecodeOptions.MaxListElements+1),
+			AppendByte,
+		)
+
+		_, err := DefaultDecodeOptions.Decode(input)
+		assert.ErrorIs(t, err, ErrMaxListElements)
+	})
+
+	t.Run("concatenated items", func(t *testing.T) {
+		t.Parallel()
+
+		value, rest, err := DefaultDecodeOptions.SplitValue(hexToBytes(t, "c0c0"))
+		require.NoError(t, err)
+
+		assert.Equal(t, NewList(), value)
+		assert.Equal(t, EmptyArray, rest)
+
+		_, err = DefaultDecodeOptions.Decode(hexToBytes(t, "c0c0"))
+		assert.ErrorIs(t, err, ErrTrailingBytes)
+	})
+}
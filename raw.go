@@ -0,0 +1,127 @@
+package ethrlp
+
+import (
+	"fmt"
+)
+
+// RawValue is an RLP item kept in its original encoding. Its content is decoded
+// only on demand, so (large) sub-structures can be forwarded or hashed
+// without materializing their elements.
+//
+// A RawValue can be embedded in a ListValue, in which case it is encoded as-is
+type RawValue struct {
+	raw  []byte // entire item encoding (header and content)
+	kind Type   // type of the encoded item
+}
+
+// DecodeRaw wraps the given RLP item in a RawValue, without decoding its content.
+// Only the item header is validated (as with Split), and the input
+// needs to hold exactly one RLP item. The RawValue shares the memory of the input
+func DecodeRaw(input []byte) (RawValue, error) {
+	raw, rest, err := SplitRaw(input)
+	if err != nil {
+		return RawValue{}, err
+	}
+
+	if len(rest) != 0 {
+		return RawValue{}, fmt.Errorf("%w: %dB", ErrTrailingBytes, len(rest))
+	}
+
+	return raw, nil
+}
+
+// SplitRaw wraps the first RLP item in the given bytes in a RawValue,
+// and returns it along with the bytes that follow it.
+// The RawValue shares the memory of the input
+func SplitRaw(input []byte) (RawValue, []byte, error) {
+	kind, _, rest, err := Split(input)
+	if err != nil {
+		return RawValue{}, nil, err
+	}
+
+	return RawValue{
+		raw:  input[:len(input)-len(rest)],
+		kind: kind,
+	}, rest, nil
+}
+
+func (r RawValue) GetType() Type {
+	return Raw
+}
+
+func (r RawValue) GetValue() any {
+	return r.raw
+}
+
+// Raw returns the original encoding of the item (header and content)
+func (r RawValue) Raw() []byte {
+	return r.raw
+}
+
+// Kind returns the type of the encoded item (Bytes or List)
+func (r RawValue) Kind() Type {
+	return r.kind
+}
+
+// Decode fully decodes the item, as with DecodeStrict.
+// Nested items that are not in canonical form are rejected
+func (r RawValue) Decode() (Value, error) {
+	return DecodeStrict(r.raw)
+}
+
+// Elements splits the item, which needs to be a list, into its elements.
+// The elements are not decoded, and share the memory of the item
+func (r RawValue) Elements() ([]RawValue, error) {
+	if r.kind != List {
+		return nil, ErrExpectedList
+	}
+
+	_, content, _, err := Split(r.raw)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]RawValue, 0)
+
+	for index := 0; len(content) > 0; index++ {
+		var element RawValue
+
+		if element, content, err = SplitRaw(content); err != nil {
+			return nil, fmt.Errorf("unable to split element %d, %w", index, err)
+		}
+
+		elements = append(elements, element)
+	}
+
+	return elements, nil
+}
+
+// asBytes decodes the item, which needs to be a byte string, into a BytesValue
+func (r RawValue) asBytes() (BytesValue, error) {
+	kind, content, _, err := Split(r.raw)
+	if err != nil {
+		return BytesValue{}, err
+	}
+
+	if kind != Bytes {
+		return BytesValue{}, ErrExpectedString
+	}
+
+	return NewBytes(content), nil
+}
+
+// asList decodes the item, which needs to be a list, into a ListValue
+// whose elements are kept in their original encoding
+func (r RawValue) asList() (ListValue, error) {
+	elements, err := r.Elements()
+	if err != nil {
+		return ListValue{}, err
+	}
+
+	values := make([]Value, len(elements))
+	for index, element := range elements {
+		values[index] = element
+	}
+
+	return NewList(values...), nil
+}
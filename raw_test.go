@@ -0,0 +1,167 @@
+package ethrlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawValue_Decode(t *testing.T) {
+	t.Parallel()
+
+	input := hexToBytes(t, "c88363617483646f67")
+
+	raw, err := DecodeRaw(input)
+	require.NoError(t, err)
+
+	assert.Equal(t, Raw, raw.GetType())
+	assert.Equal(t, List, raw.Kind())
+	assert.Equal(t, input, raw.Raw())
+
+	decoded, err := raw.Decode()
+	require.NoError(t, err)
+
+	assert.Equal(t, NewList(NewString("cat"), NewString("dog")), decoded)
+}
+
+func TestRawValue_Elements(t *testing.T) {
+	t.Parallel()
+
+	// [[], "dog", [0x05]]
+	input := hexToBytes(t, "c7c083646f67c105")
+
+	raw, err := DecodeRaw(input)
+	require.NoError(t, err)
+
+	elements, err := raw.Elements()
+	require.NoError(t, err)
+	require.Len(t, elements, 3)
+
+	assert.Equal(t, hexToBytes(t, "c0"), elements[0].Raw())
+	assert.Equal(t, hexToBytes(t, "83646f67"), elements[1].Raw())
+	assert.Equal(t, hexToBytes(t, "c105"), elements[2].Raw())
+
+	assert.Equal(t, Bytes, elements[1].Kind())
+
+	// Make sure the elements share the input memory
+	input[3] = 'D'
+
+	assert.Equal(t, "Dog", string(elements[1].Raw()[1:]))
+
+	// Byte strings have no elements
+	_, err = elements[1].Elements()
+	assert.ErrorIs(t, err, ErrExpectedList)
+}
+
+func TestRawValue_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		expectedErr error
+		name        string
+		input       []byte
+	}{
+		{
+			ErrInvalidLength,
+			"empty input",
+			[]byte{},
+		},
+		{
+			ErrTrailingBytes,
+			"trailing bytes",
+			hexToBytes(t, "c00102"),
+		},
+		{
+			ErrInvalidLength,
+			"truncated list",
+			hexToBytes(t, "c483646f"),
+		},
+		{
+			ErrNonCanonicalLength,
+			"non-canonical header",
+			hexToBytes(t, "b803646f67"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeRaw(testCase.input)
+
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+
+	t.Run("invalid element", func(t *testing.T) {
+		t.Parallel()
+
+		// The list header is valid, but the second element is truncated
+		raw, err := DecodeRaw(hexToBytes(t, "c40583646f"))
+		require.NoError(t, err)
+
+		_, err = raw.Elements()
+		assert.ErrorIs(t, err, ErrInvalidLength)
+		assert.ErrorContains(t, err, "element 1")
+	})
+
+	t.Run("non-canonical nested item", func(t *testing.T) {
+		t.Parallel()
+
+		// The list header is canonical, but the nested single byte is not
+		raw, err := DecodeRaw(hexToBytes(t, "c28105"))
+		require.NoError(t, err)
+
+		_, err = raw.Decode()
+		assert.ErrorIs(t, err, ErrCanonSize)
+	})
+}
+
+func TestRawValue_Embedded(t *testing.T) {
+	t.Parallel()
+
+	tx := hexToBytes(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+
+	raw, err := DecodeRaw(tx)
+	require.NoError(t, err)
+
+	// The embedded value is encoded as-is
+	body := NewList(NewList(raw, raw), NewList())
+	encoded := EncodeValue(body)
+
+	decoded, err := DecodeBytes(encoded)
+	require.NoError(t, err)
+
+	expected, err := raw.Decode()
+	require.NoError(t, err)
+
+	first, err := Get(decoded, 0, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, first)
+
+	// The (raw) block body is navigated lazily
+	rawBody, err := DecodeRaw(encoded)
+	require.NoError(t, err)
+
+	second, err := Get(rawBody, 0, 1)
+	require.NoError(t, err)
+
+	rawTx, ok := second.(RawValue)
+	require.True(t, ok)
+
+	assert.Equal(t, tx, rawTx.Raw())
+
+	nonce, err := GetBytes(rawBody, 0, 1, 0)
+	require.NoError(t, err)
+
+	value, err := nonce.Uint64()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(9), value)
+
+	// Byte strings cannot be navigated
+	_, err = GetList(rawBody, 0, 1, 0)
+	assert.ErrorIs(t, err, ErrExpectedList)
+}
@@ -0,0 +1,44 @@
+package ethrlp
+
+import "fmt"
+
+// EncodeSlice encodes the given items as an RLP list.
+// Every item is encoded by the given callback, which appends its
+// RLP encoding to the list buffer (for example, AppendUint64).
+// Unlike EncodeArray, the items are not encoded separately
+func EncodeSlice[T any](items []T, enc func(dst []byte, item T) []byte) []byte {
+	return AppendSlice(nil, items, enc)
+}
+
+// AppendSlice appends the given items as an RLP list to dst,
+// and returns the extended byte array.
+// Every item is encoded by the given callback, as with EncodeSlice
+func AppendSlice[T any](dst []byte, items []T, enc func(dst []byte, item T) []byte) []byte {
+	return AppendList(dst, func(dst []byte) []byte {
+		for _, item := range items {
+			dst = enc(dst, item)
+		}
+
+		return dst
+	})
+}
+
+// DecodeSlice decodes the given value, which needs to be a list,
+// into a slice of items. Every list element is decoded by the given callback.
+// The returned error names the index of the element that failed
+func DecodeSlice[T any](v Value, dec func(Value) (T, error)) ([]T, error) {
+	list, err := AsList(v)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]T, list.Len())
+
+	for index, element := range list.All() {
+		if items[index], err = dec(element); err != nil {
+			return nil, fmt.Errorf("element %d: %w", index, err)
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,160 @@
+package ethrlp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeUint decodes the given value as an unsigned integer
+func decodeUint(v Value) (uint64, error) {
+	b, err := AsBytes(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.Uint64()
+}
+
+func TestEncodeSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsigned integers", func(t *testing.T) {
+		t.Parallel()
+
+		items := []uint64{0, 1, 0x7f, 0x80, 1024}
+
+		assert.Equal(
+			t,
+			EncodeArray([][]byte{
+				EncodeUint(0),
+				EncodeUint(1),
+				EncodeUint(0x7f),
+				EncodeUint(0x80),
+				EncodeUint(1024),
+			}),
+			EncodeSlice(items, AppendUint64),
+		)
+	})
+
+	t.Run("long list", func(t *testing.T) {
+		t.Parallel()
+
+		items := []string{
+			"Lorem ipsum dolor sit amet",
+			"consectetur adipisicing elit",
+			"sed do eiusmod tempor",
+		}
+
+		assert.Equal(
+			t,
+			EncodeArray([][]byte{
+				EncodeString(items[0]),
+				EncodeString(items[1]),
+				EncodeString(items[2]),
+			}),
+			EncodeSlice(items, AppendString),
+		)
+	})
+
+	t.Run("nested slices", func(t *testing.T) {
+		t.Parallel()
+
+		items := [][]uint64{{1, 2}, {}, {3}}
+
+		encoded := EncodeSlice(items, func(dst []byte, item []uint64) []byte {
+			return AppendSlice(dst, item, AppendUint64)
+		})
+
+		assert.Equal(t, hexToBytes(t, "c6c20102c0c103"), encoded)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, EmptyArray, EncodeSlice(nil, AppendBigInt))
+		assert.Equal(t, EmptyArray, EncodeSlice([]*big.Int{}, AppendBigInt))
+	})
+
+	t.Run("existing buffer", func(t *testing.T) {
+		t.Parallel()
+
+		dst := AppendSlice([]byte{0x01}, []bool{true, false}, AppendBool)
+
+		assert.Equal(t, hexToBytes(t, "01c20180"), dst)
+	})
+}
+
+func TestDecodeSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		items := []uint64{0, 1, 0x7f, 0x80, 1024, 0xffffffffffffffff}
+
+		value, err := DecodeBytes(EncodeSlice(items, AppendUint64))
+		require.NoError(t, err)
+
+		decoded, err := DecodeSlice(value, decodeUint)
+		require.NoError(t, err)
+
+		assert.Equal(t, items, decoded)
+	})
+
+	t.Run("nested slices", func(t *testing.T) {
+		t.Parallel()
+
+		value, err := DecodeBytes(hexToBytes(t, "c6c20102c0c103"))
+		require.NoError(t, err)
+
+		decoded, err := DecodeSlice(value, func(v Value) ([]uint64, error) {
+			return DecodeSlice(v, decodeUint)
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]uint64{{1, 2}, {}, {3}}, decoded)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := DecodeSlice(NewList(), decodeUint)
+		require.NoError(t, err)
+
+		assert.Empty(t, decoded)
+	})
+
+	t.Run("not a list", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecodeSlice(NewUint(1), decodeUint)
+		assert.ErrorIs(t, err, ErrExpectedList)
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		t.Parallel()
+
+		value := NewList(NewUint(1), NewUint(2), NewList())
+
+		_, err := DecodeSlice(value, decodeUint)
+
+		assert.ErrorIs(t, err, ErrExpectedString)
+		assert.ErrorContains(t, err, "element 2: ")
+	})
+
+	t.Run("invalid nested element", func(t *testing.T) {
+		t.Parallel()
+
+		value := NewList(NewList(NewUint(1)), NewList(NewUint(2), NewBytes([]byte{0x00, 0x01})))
+
+		_, err := DecodeSlice(value, func(v Value) ([]uint64, error) {
+			return DecodeSlice(v, decodeUint)
+		})
+
+		assert.ErrorIs(t, err, ErrCanonInt)
+		assert.ErrorContains(t, err, "element 1: element 1: ")
+	})
+}
@@ -0,0 +1,104 @@
+package ethrlp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	ErrExpectedString = errors.New("expected string, got list")
+	ErrExpectedList   = errors.New("expected list, got bytes")
+)
+
+// Split returns the type and content of the first RLP item in the given bytes,
+// along with the bytes that follow it. Split does not allocate, and the returned
+// content and rest share the memory of the input.
+//
+// The item header is required to be in its canonical form, as with DecodeStrict
+func Split(input []byte) (Type, []byte, []byte, error) {
+	return splitItem(input, true)
+}
+
+// SplitString splits the given bytes into the content of
+// the first RLP item, and the bytes that follow it.
+// The first RLP item needs to be a byte string
+func SplitString(input []byte) ([]byte, []byte, error) {
+	kind, content, rest, err := Split(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if kind != Bytes {
+		return nil, nil, ErrExpectedString
+	}
+
+	return content, rest, nil
+}
+
+// SplitList splits the given bytes into the content of
+// the first RLP item, and the bytes that follow it.
+// The first RLP item needs to be a list
+func SplitList(input []byte) ([]byte, []byte, error) {
+	kind, content, rest, err := Split(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if kind != List {
+		return nil, nil, ErrExpectedList
+	}
+
+	return content, rest, nil
+}
+
+// SplitUint64 decodes an unsigned integer from the beginning of the given bytes,
+// and returns it along with the bytes that follow it. The integer needs
+// to be at most 8B long, and cannot have leading zero bytes
+func SplitUint64(input []byte) (uint64, []byte, error) {
+	content, rest, err := SplitString(input)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	value, err := decodeUint64(content)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return value, rest, nil
+}
+
+// SplitBigInt decodes a big integer from the beginning of the given bytes,
+// and returns it along with the bytes that follow it.
+// The integer cannot have leading zero bytes
+func SplitBigInt(input []byte) (*big.Int, []byte, error) {
+	content, rest, err := SplitString(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value, err := decodeBigInt(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, rest, nil
+}
+
+// CountValues counts the number of RLP items in the given bytes.
+// It is usually called with the content of a list, returned by SplitList
+func CountValues(input []byte) (int, error) {
+	count := 0
+
+	for ; len(input) > 0; count++ {
+		_, _, rest, err := Split(input)
+		if err != nil {
+			return 0, fmt.Errorf("unable to split item %d, %w", count, err)
+		}
+
+		input = rest
+	}
+
+	return count, nil
+}
@@ -0,0 +1,249 @@
+package ethrlp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name            string
+		input           []byte
+		expectedContent []byte
+		expectedRest    []byte
+		expectedType    Type
+	}{
+		{
+			"single byte",
+			hexToBytes(t, "05ff"),
+			hexToBytes(t, "05"),
+			hexToBytes(t, "ff"),
+			Bytes,
+		},
+		{
+			"empty string",
+			hexToBytes(t, "80"),
+			[]byte{},
+			[]byte{},
+			Bytes,
+		},
+		{
+			"short string",
+			hexToBytes(t, "83646f67c0"),
+			[]byte("dog"),
+			hexToBytes(t, "c0"),
+			Bytes,
+		},
+		{
+			"long string",
+			EncodeString("Lorem ipsum dolor sit amet, consectetur adipisicing elit"),
+			[]byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit"),
+			[]byte{},
+			Bytes,
+		},
+		{
+			"empty list",
+			hexToBytes(t, "c080"),
+			[]byte{},
+			hexToBytes(t, "80"),
+			List,
+		},
+		{
+			"short list",
+			hexToBytes(t, "c88363617483646f67"),
+			hexToBytes(t, "8363617483646f67"),
+			[]byte{},
+			List,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			kind, content, rest, err := Split(testCase.input)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedType, kind)
+			assert.Equal(t, testCase.expectedContent, content)
+			assert.Equal(t, testCase.expectedRest, rest)
+		})
+	}
+}
+
+func TestSplit_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		expectedErr error
+		name        string
+		input       []byte
+	}{
+		{
+			ErrInvalidLength,
+			"empty input",
+			[]byte{},
+		},
+		{
+			ErrInvalidLength,
+			"truncated string",
+			hexToBytes(t, "83646f"),
+		},
+		{
+			ErrCanonSize,
+			"non-canonical single byte",
+			hexToBytes(t, "8105"),
+		},
+		{
+			ErrNonCanonicalLength,
+			"non-canonical long string",
+			hexToBytes(t, "b803646f67"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, _, err := Split(testCase.input)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestSplit_StringList(t *testing.T) {
+	t.Parallel()
+
+	input := hexToBytes(t, "c88363617483646f67")
+
+	// Split the list
+	content, rest, err := SplitList(input)
+	require.NoError(t, err)
+
+	assert.Empty(t, rest)
+
+	_, _, err = SplitString(input)
+	assert.ErrorIs(t, err, ErrExpectedString)
+
+	// Split the list elements
+	cat, content, err := SplitString(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("cat"), cat)
+
+	_, _, err = SplitList(content)
+	assert.ErrorIs(t, err, ErrExpectedList)
+
+	dog, content, err := SplitString(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("dog"), dog)
+	assert.Empty(t, content)
+}
+
+func TestCountValues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid list content", func(t *testing.T) {
+		t.Parallel()
+
+		count, err := CountValues(hexToBytes(t, "058363617483646f67c0c105"))
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, count)
+	})
+
+	t.Run("empty list content", func(t *testing.T) {
+		t.Parallel()
+
+		count, err := CountValues([]byte{})
+		require.NoError(t, err)
+
+		assert.Zero(t, count)
+	})
+
+	t.Run("truncated list content", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := CountValues(hexToBytes(t, "058363617483646f"))
+		assert.ErrorIs(t, err, ErrInvalidLength)
+	})
+}
+
+func TestSplit_NoAllocations(t *testing.T) {
+	// AllocsPerRun cannot be used in parallel tests
+	input := hexToBytes(t, "c88363617483646f67")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		//nolint:errcheck // No need to check for errors
+		_, _ = CountValues(input[1:])
+	})
+
+	assert.Zero(t, allocs)
+}
+
+func TestSplit_Integers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid integers", func(t *testing.T) {
+		t.Parallel()
+
+		input := hexToBytes(t, "80058204008a01000000000000000000")
+
+		zero, rest, err := SplitUint64(input)
+		require.NoError(t, err)
+
+		assert.Zero(t, zero)
+
+		small, rest, err := SplitUint64(rest)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(5), small)
+
+		medium, rest, err := SplitBigInt(rest)
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(1024), medium)
+
+		_, _, err = SplitUint64(rest)
+		assert.ErrorIs(t, err, ErrUintOverflow)
+
+		large, rest, err := SplitBigInt(rest)
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(0).Lsh(big.NewInt(1), 72), large)
+		assert.Empty(t, rest)
+	})
+
+	t.Run("leading zeros", func(t *testing.T) {
+		t.Parallel()
+
+		input := hexToBytes(t, "820001")
+
+		_, _, err := SplitUint64(input)
+		assert.ErrorIs(t, err, ErrCanonInt)
+
+		_, _, err = SplitBigInt(input)
+		assert.ErrorIs(t, err, ErrCanonInt)
+	})
+
+	t.Run("zero byte", func(t *testing.T) {
+		t.Parallel()
+
+		// Zero is encoded as an empty string, not as 0x00
+		_, _, err := SplitUint64(hexToBytes(t, "00"))
+		assert.ErrorIs(t, err, ErrCanonInt)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := SplitBigInt(hexToBytes(t, "c0"))
+		assert.ErrorIs(t, err, ErrExpectedString)
+	})
+}
@@ -0,0 +1,422 @@
+package ethrlp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInputLimit   = errors.New("input limit exceeded")
+	ErrEndOfList    = errors.New("end of list")
+	ErrNotInList    = errors.New("not positioned in a list")
+	ErrNotAtListEnd = errors.New("not positioned at the end of the list")
+)
+
+// maxHeaderSize is the maximum size of an RLP header
+// (a single prefix byte followed by at most 8 length bytes)
+const maxHeaderSize = 9
+
+// streamChunkSize is the maximum size (in bytes) of a single content read
+// of an unlimited stream. The content buffer grows as the data arrives,
+// so an untrusted header cannot force a large upfront allocation
+const streamChunkSize = 64 * 1024
+
+// Stream is a piecewise RLP decoder that reads from an io.Reader,
+// without requiring the entire input to be buffered in memory.
+// The Stream enforces canonical encoding for every item it reads, as with DecodeStrict.
+//
+// Lists are traversed by calling List, decoding the list elements,
+// and finally calling ListEnd once all elements have been read
+type Stream struct {
+	r io.Reader
+
+	// stack holds the remaining content size
+	// (in bytes) of every currently open list
+	stack []uint64
+
+	kindErr error    // error of the last header read
+	meta    metadata // metadata of the last header read
+	size    uint64   // content size of the last header read
+
+	remaining uint64 // remaining input limit (in bytes)
+
+	header    [maxHeaderSize]byte // raw bytes of the last header read
+	headerLen int                 // length of the last header read
+
+	kind      Type // type of the last header read
+	kindRead  bool // flag indicating if the next header was read
+	isLimited bool // flag indicating if the input limit is enforced
+}
+
+// NewStream creates a new RLP decoding stream that reads from the given reader.
+//
+// If inputLimit is non-zero, the Stream will refuse to read more
+// than inputLimit bytes in total. If inputLimit is zero, and the reader
+// is a *bytes.Reader, *bytes.Buffer or *strings.Reader, the limit is set
+// to the length of the remaining data. Otherwise, the input is not limited,
+// and item content is read in bounded chunks, as it arrives
+func NewStream(r io.Reader, inputLimit uint64) *Stream {
+	s := &Stream{}
+	s.Reset(r, inputLimit)
+
+	return s
+}
+
+// Reset discards the stream state, and starts
+// reading from the given reader with the given input limit
+func (s *Stream) Reset(r io.Reader, inputLimit uint64) {
+	s.r = r
+	s.stack = s.stack[:0]
+	s.kindRead = false
+	s.kindErr = nil
+
+	s.remaining = inputLimit
+	s.isLimited = inputLimit != 0
+
+	if s.isLimited {
+		return
+	}
+
+	// Attempt to derive the limit from the reader
+	switch br := r.(type) {
+	case *bytes.Reader:
+		s.remaining, s.isLimited = uint64(br.Len()), true
+	case *bytes.Buffer:
+		s.remaining, s.isLimited = uint64(br.Len()), true
+	case *strings.Reader:
+		s.remaining, s.isLimited = uint64(br.Len()), true
+	}
+}
+
+// Kind returns the type and content size of the next RLP item in the stream,
+// without consuming it. ErrEndOfList is returned if the stream
+// is positioned at the end of the current list, and io.EOF
+// if there are no more top-level items in the stream
+func (s *Stream) Kind() (Type, uint64, error) {
+	if s.kindRead {
+		return s.kind, s.size, s.kindErr
+	}
+
+	// Check if the current list is fully read
+	if len(s.stack) > 0 && s.stack[len(s.stack)-1] == 0 {
+		return 0, 0, ErrEndOfList
+	}
+
+	s.kind, s.size, s.kindErr = s.readKind()
+	s.kindRead = true
+
+	return s.kind, s.size, s.kindErr
+}
+
+// Bytes reads the next RLP item from the stream,
+// and returns its content. The item needs to be a byte string
+func (s *Stream) Bytes() ([]byte, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+
+	if kind != Bytes {
+		return nil, ErrExpectedString
+	}
+
+	return s.readContent([]byte{}, size)
+}
+
+// Uint64 reads the next RLP item from the stream,
+// and decodes it as an unsigned integer.
+// The item needs to be a byte string of at most 8B,
+// with no leading zero bytes
+func (s *Stream) Uint64() (uint64, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return 0, err
+	}
+
+	if kind != Bytes {
+		return 0, ErrExpectedString
+	}
+
+	if size > 8 {
+		return 0, fmt.Errorf("%w: %dB", ErrUintOverflow, size)
+	}
+
+	var buf [8]byte
+
+	content, err := s.readContent(buf[:0], size)
+	if err != nil {
+		return 0, err
+	}
+
+	return decodeUint64(content)
+}
+
+// BigInt reads the next RLP item from the stream,
+// and decodes it as a (non-negative) big integer.
+// The item needs to be a byte string with no leading zero bytes
+func (s *Stream) BigInt() (*big.Int, error) {
+	content, err := s.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeBigInt(content)
+}
+
+// List enters the next RLP item in the stream, which needs to be a list,
+// and returns its content size. Subsequent reads return the list elements,
+// until ListEnd is called
+func (s *Stream) List() (uint64, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return 0, err
+	}
+
+	if kind != List {
+		return 0, ErrExpectedList
+	}
+
+	// The list content is accounted for by the new list
+	if len(s.stack) > 0 {
+		s.stack[len(s.stack)-1] -= size
+	}
+
+	s.stack = append(s.stack, size)
+	s.kindRead = false
+
+	return size, nil
+}
+
+// ListEnd leaves the current list. All list elements
+// need to be read before the list can be left
+func (s *Stream) ListEnd() error {
+	if len(s.stack) == 0 {
+		return ErrNotInList
+	}
+
+	if s.stack[len(s.stack)-1] != 0 {
+		return ErrNotAtListEnd
+	}
+
+	s.stack = s.stack[:len(s.stack)-1]
+	s.kindRead = false
+
+	return nil
+}
+
+// Raw reads the next RLP item from the stream,
+// and returns its entire encoding (header and content)
+func (s *Stream) Raw() ([]byte, error) {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.meta.dataType == byteType {
+		s.kindRead = false
+
+		return []byte{s.header[0]}, nil
+	}
+
+	raw := append([]byte{}, s.header[:s.headerLen]...)
+
+	if kind == Bytes {
+		// Make sure the content is canonical
+		return s.readContent(raw, size)
+	}
+
+	raw, err = s.readSized(raw, size)
+	if err != nil {
+		return nil, err
+	}
+
+	s.kindRead = false
+
+	return raw, nil
+}
+
+// Decode reads the next RLP item from the stream,
+// and passes its decoded value on to the given decoder
+func (s *Stream) Decode(d RLPDecoder) error {
+	raw, err := s.Raw()
+	if err != nil {
+		return err
+	}
+
+	// The stream has already verified the top-level header,
+	// but the nested items are yet to be checked
+	value, err := DecodeStrict(raw)
+	if err != nil {
+		return err
+	}
+
+	return d.DecodeRLP(value)
+}
+
+// readKind reads the next RLP header from the stream,
+// and returns the type and content size of the item
+func (s *Stream) readKind() (Type, uint64, error) {
+	// Check if the input is fully read
+	if len(s.stack) == 0 && s.isLimited && s.remaining == 0 {
+		return 0, 0, io.EOF
+	}
+
+	// Read the first header byte, which determines the header size
+	if err := s.readFull(s.header[:1]); err != nil {
+		if errors.Is(err, io.EOF) && len(s.stack) != 0 {
+			// The list is missing elements
+			return 0, 0, io.ErrUnexpectedEOF
+		}
+
+		return 0, 0, err
+	}
+
+	s.headerLen = headerSize(s.header[0])
+
+	if err := s.readFull(s.header[1:s.headerLen]); err != nil {
+		return 0, 0, unexpectedEOF(err)
+	}
+
+	meta, err := parseHeader(s.header[:s.headerLen])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The short string check requires the content byte,
+	// so it is done once the content is read
+	if meta.dataType != shortBytesType {
+		if err := checkCanonical(s.header[:s.headerLen], meta); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	s.meta = meta
+
+	kind := Bytes
+	if meta.dataType == shortArrayType || meta.dataType == longArrayType {
+		kind = List
+	}
+
+	// A single byte value is its own content (it has already been read)
+	if meta.dataType == byteType {
+		return kind, 1, nil
+	}
+
+	size := uint64(meta.dataLength - meta.dataOffset)
+
+	// Make sure the item fits into the current list
+	if len(s.stack) > 0 && size > s.stack[len(s.stack)-1] {
+		return 0, 0, fmt.Errorf(
+			"%w: expected %dB, got %dB",
+			ErrInvalidLength,
+			size,
+			s.stack[len(s.stack)-1],
+		)
+	}
+
+	// Make sure the item fits into the input limit
+	if s.isLimited && size > s.remaining {
+		return 0, 0, fmt.Errorf("%w: item size %dB", ErrInputLimit, size)
+	}
+
+	return kind, size, nil
+}
+
+// readContent reads the content (of the given size) of the byte string
+// whose header was last read, and appends it to dst
+func (s *Stream) readContent(dst []byte, size uint64) ([]byte, error) {
+	s.kindRead = false
+
+	// A single byte value is its own content
+	if s.meta.dataType == byteType {
+		return append(dst, s.header[0]), nil
+	}
+
+	start := len(dst)
+
+	dst, err := s.readSized(dst, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single byte in the [0x00, 0x7f] range
+	// is its own encoding, and cannot be prefixed
+	if size == 1 && dst[start] <= 0x7f {
+		return nil, ErrCanonSize
+	}
+
+	return dst, nil
+}
+
+// readSized reads the given number of bytes, and appends them to dst.
+// If the stream is not limited, the size comes from an unverified header,
+// so the bytes are read (and dst is grown) in bounded chunks
+func (s *Stream) readSized(dst []byte, size uint64) ([]byte, error) {
+	chunkSize := uint64(streamChunkSize)
+	if s.isLimited {
+		// The size has already been checked against the remaining input
+		chunkSize = size
+	}
+
+	for size > 0 {
+		var (
+			chunk = int(min(size, chunkSize))
+			start = len(dst)
+		)
+
+		dst = slices.Grow(dst, chunk)[:start+chunk]
+
+		if err := s.readFull(dst[start:]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+
+		size -= uint64(chunk)
+	}
+
+	return dst, nil
+}
+
+// readFull reads exactly len(buf) bytes from the underlying reader,
+// accounting for the input limit and the current list size
+func (s *Stream) readFull(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+
+	size := uint64(len(buf))
+
+	if s.isLimited && size > s.remaining {
+		return ErrInputLimit
+	}
+
+	if len(s.stack) > 0 && size > s.stack[len(s.stack)-1] {
+		return constructLengthError(len(buf), int(s.stack[len(s.stack)-1]))
+	}
+
+	if _, err := io.ReadFull(s.r, buf); err != nil {
+		return err
+	}
+
+	s.remaining -= size
+
+	if len(s.stack) > 0 {
+		s.stack[len(s.stack)-1] -= size
+	}
+
+	return nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF,
+// for reads that happen in the middle of an RLP item
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
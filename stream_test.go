@@ -0,0 +1,388 @@
+package ethrlp
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// onlyReader hides the concrete type of the wrapped reader,
+// so the Stream cannot derive the input limit from it
+type onlyReader struct {
+	io.Reader
+}
+
+func TestStream_Kind(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name         string
+		input        []byte
+		expectedSize uint64
+		expectedType Type
+	}{
+		{
+			"single byte",
+			hexToBytes(t, "05"),
+			1,
+			Bytes,
+		},
+		{
+			"empty string",
+			hexToBytes(t, "80"),
+			0,
+			Bytes,
+		},
+		{
+			"short string",
+			hexToBytes(t, "83646f67"),
+			3,
+			Bytes,
+		},
+		{
+			"long string",
+			EncodeString("Lorem ipsum dolor sit amet, consectetur adipisicing elit"),
+			56,
+			Bytes,
+		},
+		{
+			"short list",
+			hexToBytes(t, "c88363617483646f67"),
+			8,
+			List,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewStream(bytes.NewReader(testCase.input), 0)
+
+			kind, size, err := s.Kind()
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedType, kind)
+			assert.Equal(t, testCase.expectedSize, size)
+
+			// Make sure the header is not consumed
+			kind, size, err = s.Kind()
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedType, kind)
+			assert.Equal(t, testCase.expectedSize, size)
+		})
+	}
+}
+
+func TestStream_EIP155(t *testing.T) {
+	t.Parallel()
+
+	input := hexToBytes(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+
+	s := NewStream(onlyReader{bytes.NewReader(input)}, 0)
+
+	size, err := s.List()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(108), size)
+
+	// Decode the nonce
+	nonce, err := s.Uint64()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(9), nonce)
+
+	// Decode the gas price
+	gasPrice, err := s.Uint64()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(20000000000), gasPrice)
+
+	// Decode the gas limit
+	gasLimit, err := s.Uint64()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(21000), gasLimit)
+
+	// Decode the recipient address
+	to, err := s.Bytes()
+	require.NoError(t, err)
+
+	assert.Equal(t, hexToBytes(t, "3535353535353535353535353535353535353535"), to)
+
+	// Decode the value
+	value, err := s.Uint64()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1000000000000000000), value)
+
+	// Decode the data
+	data, err := s.Bytes()
+	require.NoError(t, err)
+
+	assert.Empty(t, data)
+
+	// Decode the v sig param
+	v, err := s.Uint64()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(37), v)
+
+	// Make sure the list can't be left early
+	assert.ErrorIs(t, s.ListEnd(), ErrNotAtListEnd)
+
+	// Decode the r sig param (raw)
+	r, err := s.Raw()
+	require.NoError(t, err)
+
+	assert.Equal(t, hexToBytes(t, "a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"), r)
+
+	// Decode the s sig param
+	sig, err := s.Bytes()
+	require.NoError(t, err)
+
+	assert.Equal(t, hexToBytes(t, "67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"), sig)
+
+	// Make sure the list is fully read
+	_, _, err = s.Kind()
+	assert.ErrorIs(t, err, ErrEndOfList)
+
+	require.NoError(t, s.ListEnd())
+
+	// Make sure the input is fully read
+	_, _, err = s.Kind()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestStream_ConcatenatedItems(t *testing.T) {
+	t.Parallel()
+
+	input := hexToBytes(t, "83646f67c7c0c1c0c3c0c1c0")
+	s := NewStream(bytes.NewReader(input), 0)
+
+	first, err := s.Raw()
+	require.NoError(t, err)
+
+	assert.Equal(t, hexToBytes(t, "83646f67"), first)
+
+	second, err := s.Raw()
+	require.NoError(t, err)
+
+	assert.Equal(t, hexToBytes(t, "c7c0c1c0c3c0c1c0"), second)
+
+	_, err = s.Raw()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestStream_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		read        func(s *Stream) error
+		expectedErr error
+		name        string
+		input       []byte
+		inputLimit  uint64
+	}{
+		{
+			func(s *Stream) error {
+				_, err := s.Bytes()
+
+				return err
+			},
+			ErrExpectedString,
+			"list read as bytes",
+			hexToBytes(t, "c0"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.List()
+
+				return err
+			},
+			ErrExpectedList,
+			"bytes read as list",
+			hexToBytes(t, "80"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Uint64()
+
+				return err
+			},
+			ErrCanonInt,
+			"integer with leading zeros",
+			hexToBytes(t, "820001"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Uint64()
+
+				return err
+			},
+			ErrUintOverflow,
+			"integer larger than 8B",
+			hexToBytes(t, "89010000000000000000"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Bytes()
+
+				return err
+			},
+			ErrCanonSize,
+			"non-canonical single byte",
+			hexToBytes(t, "8105"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Bytes()
+
+				return err
+			},
+			ErrNonCanonicalLength,
+			"non-canonical long string",
+			hexToBytes(t, "b803646f67"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Bytes()
+
+				return err
+			},
+			io.ErrUnexpectedEOF,
+			"truncated string",
+			hexToBytes(t, "83646f"),
+			10,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Bytes()
+
+				return err
+			},
+			ErrInputLimit,
+			"string over the input limit",
+			hexToBytes(t, "83646f67"),
+			3,
+		},
+		{
+			func(s *Stream) error {
+				if _, err := s.List(); err != nil {
+					return err
+				}
+
+				_, err := s.Bytes()
+
+				return err
+			},
+			ErrInvalidLength,
+			"element larger than the list",
+			hexToBytes(t, "c283646f67"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.BigInt()
+
+				return err
+			},
+			ErrCanonInt,
+			"big integer with leading zeros",
+			hexToBytes(t, "820001"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Bytes()
+
+				return err
+			},
+			io.ErrUnexpectedEOF,
+			"unlimited string with an oversized header",
+			hexToBytes(t, "bf7ffffffffffffff001"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				_, err := s.Raw()
+
+				return err
+			},
+			io.ErrUnexpectedEOF,
+			"unlimited raw list with an oversized header",
+			hexToBytes(t, "ff7ffffffffffffff001"),
+			0,
+		},
+		{
+			func(s *Stream) error {
+				return s.ListEnd()
+			},
+			ErrNotInList,
+			"list end outside a list",
+			hexToBytes(t, "c0"),
+			0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewStream(onlyReader{bytes.NewReader(testCase.input)}, testCase.inputLimit)
+
+			assert.ErrorIs(t, testCase.read(s), testCase.expectedErr)
+		})
+	}
+}
+
+func TestStream_UnlimitedReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("oversized header", func(t *testing.T) {
+		t.Parallel()
+
+		// The header claims ~2^63 bytes, but only a single content byte follows
+		input := hexToBytes(t, "bf7ffffffffffffff001")
+
+		s := NewStream(io.MultiReader(bytes.NewReader(input)), 0)
+
+		kind, size, err := s.Kind()
+		require.NoError(t, err)
+
+		assert.Equal(t, Bytes, kind)
+		assert.Equal(t, uint64(9223372036854775792), size)
+
+		_, err = s.Bytes()
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("content larger than a chunk", func(t *testing.T) {
+		t.Parallel()
+
+		content := bytes.Repeat([]byte{0x01}, 3*streamChunkSize+5)
+		input := append(EncodeBytes(content), EncodeArray([][]byte{EncodeBytes(content)})...)
+
+		s := NewStream(onlyReader{bytes.NewReader(input)}, 0)
+
+		decoded, err := s.Bytes()
+		require.NoError(t, err)
+
+		assert.Equal(t, content, decoded)
+
+		raw, err := s.Raw()
+		require.NoError(t, err)
+
+		assert.Equal(t, input[len(EncodeBytes(content)):], raw)
+	})
+}
@@ -0,0 +1,208 @@
+package tx
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sig-0/ethrlp"
+)
+
+// accessListFields is the number of fields in an encoded access list transaction
+const accessListFields = 11
+
+// AccessTuple is an (EIP-2930) access list entry, encoded as the RLP list
+// [address, [storageKey, ...]]
+type AccessTuple struct {
+	StorageKeys []Hash  // accessed storage slots
+	Address     Address // accessed account
+}
+
+// AppendRLP appends the RLP encoding of the access tuple to dst,
+// and returns the extended byte array
+func (t *AccessTuple) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = ethrlp.AppendBytes(dst, t.Address[:])
+
+		return ethrlp.AppendSlice(dst, t.StorageKeys, func(dst []byte, key Hash) []byte {
+			return ethrlp.AppendBytes(dst, key[:])
+		})
+	})
+}
+
+// DecodeRLP decodes the given RLP value into the access tuple
+func (t *AccessTuple) DecodeRLP(v ethrlp.Value) error {
+	values, err := listFields(v, 2)
+	if err != nil {
+		return fieldError("AccessTuple", err)
+	}
+
+	if t.Address, err = decodeAddress(values[0]); err != nil {
+		return fieldError("AccessTuple.Address", err)
+	}
+
+	if t.StorageKeys, err = ethrlp.DecodeSlice(values[1], decodeHash); err != nil {
+		return fieldError("AccessTuple.StorageKeys", err)
+	}
+
+	return nil
+}
+
+// AccessList is an (EIP-2930) list of accounts and storage slots
+// the transaction plans to access
+type AccessList []AccessTuple
+
+// appendAccessList appends the RLP encoding of the given access list to dst
+func appendAccessList(dst []byte, list AccessList) []byte {
+	return ethrlp.AppendSlice(dst, list, func(dst []byte, tuple AccessTuple) []byte {
+		return tuple.AppendRLP(dst)
+	})
+}
+
+// decodeAccessList decodes the given RLP value as an access list
+func decodeAccessList(v ethrlp.Value) (AccessList, error) {
+	return ethrlp.DecodeSlice(v, func(v ethrlp.Value) (AccessTuple, error) {
+		var tuple AccessTuple
+
+		if err := tuple.DecodeRLP(v); err != nil {
+			return AccessTuple{}, err
+		}
+
+		return tuple, nil
+	})
+}
+
+// AccessListTx is an (EIP-2930) access list transaction, encoded as
+// 0x01 || rlp([chainID, nonce, gasPrice, gas, to, value, data, accessList, yParity, r, s])
+type AccessListTx struct {
+	ChainID    *big.Int   // chain the transaction is bound to
+	GasPrice   *big.Int   // price per unit of gas
+	To         *Address   // recipient, nil for contract creation
+	Value      *big.Int   // transferred amount
+	R          *big.Int   // signature R value
+	S          *big.Int   // signature S value
+	Data       []byte     // call data, or contract init code
+	AccessList AccessList // pre-declared accessed accounts and storage slots
+	Nonce      uint64     // sender account nonce
+	Gas        uint64     // gas limit
+	YParity    byte       // signature recovery ID (0 or 1)
+}
+
+// Type returns the transaction type (AccessListTxType)
+func (tx *AccessListTx) Type() TxType {
+	return AccessListTxType
+}
+
+// AppendRLP appends the RLP encoding of the transaction (without its type byte)
+// to dst, and returns the extended byte array.
+// The transaction needs to be valid (see Validate), otherwise AppendRLP panics.
+// Nil big integers are encoded as zero
+func (tx *AccessListTx) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = tx.appendPayload(dst)
+
+		return appendSignature(dst, tx.YParity, tx.R, tx.S)
+	})
+}
+
+// DecodeRLP decodes the given RLP value (without the type byte) into the transaction
+func (tx *AccessListTx) DecodeRLP(v ethrlp.Value) error {
+	values, err := listFields(v, accessListFields)
+	if err != nil {
+		return fieldError("AccessListTx", err)
+	}
+
+	if tx.ChainID, err = decodeBigInt(values[0]); err != nil {
+		return fieldError("AccessListTx.ChainID", err)
+	}
+
+	if tx.Nonce, err = decodeUint(values[1]); err != nil {
+		return fieldError("AccessListTx.Nonce", err)
+	}
+
+	if tx.GasPrice, err = decodeBigInt(values[2]); err != nil {
+		return fieldError("AccessListTx.GasPrice", err)
+	}
+
+	if tx.Gas, err = decodeUint(values[3]); err != nil {
+		return fieldError("AccessListTx.Gas", err)
+	}
+
+	if tx.To, err = decodeRecipient(values[4]); err != nil {
+		return fieldError("AccessListTx.To", err)
+	}
+
+	if tx.Value, err = decodeBigInt(values[5]); err != nil {
+		return fieldError("AccessListTx.Value", err)
+	}
+
+	if tx.Data, err = decodeBytes(values[6]); err != nil {
+		return fieldError("AccessListTx.Data", err)
+	}
+
+	if tx.AccessList, err = decodeAccessList(values[7]); err != nil {
+		return fieldError("AccessListTx.AccessList", err)
+	}
+
+	if tx.YParity, err = decodeYParity(values[8]); err != nil {
+		return fieldError("AccessListTx.YParity", err)
+	}
+
+	if tx.R, err = decodeBigInt(values[9]); err != nil {
+		return fieldError("AccessListTx.R", err)
+	}
+
+	if tx.S, err = decodeBigInt(values[10]); err != nil {
+		return fieldError("AccessListTx.S", err)
+	}
+
+	return nil
+}
+
+// SigningPayload returns the payload whose Keccak-256 hash is signed:
+// 0x01 || rlp([chainID, nonce, gasPrice, gas, to, value, data, accessList])
+func (tx *AccessListTx) SigningPayload() ([]byte, error) {
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ethrlp.AppendList([]byte{byte(AccessListTxType)}, tx.appendPayload), nil
+}
+
+// Validate makes sure none of the big integer fields are negative
+func (tx *AccessListTx) Validate() error {
+	return errors.Join(
+		checkBigInt("AccessListTx.ChainID", tx.ChainID),
+		checkBigInt("AccessListTx.GasPrice", tx.GasPrice),
+		checkBigInt("AccessListTx.Value", tx.Value),
+		checkBigInt("AccessListTx.R", tx.R),
+		checkBigInt("AccessListTx.S", tx.S),
+	)
+}
+
+// SetSignature sets the signature values of the transaction
+func (tx *AccessListTx) SetSignature(yParity byte, r, s *big.Int) error {
+	if err := checkYParity(yParity); err != nil {
+		return err
+	}
+
+	if err := checkSignatureValues(r, s); err != nil {
+		return err
+	}
+
+	tx.YParity, tx.R, tx.S = yParity, new(big.Int).Set(r), new(big.Int).Set(s)
+
+	return nil
+}
+
+// appendPayload appends the unsigned transaction fields to dst
+func (tx *AccessListTx) appendPayload(dst []byte) []byte {
+	dst = appendBigInt(dst, tx.ChainID)
+	dst = ethrlp.AppendUint64(dst, tx.Nonce)
+	dst = appendBigInt(dst, tx.GasPrice)
+	dst = ethrlp.AppendUint64(dst, tx.Gas)
+	dst = appendRecipient(dst, tx.To)
+	dst = appendBigInt(dst, tx.Value)
+	dst = ethrlp.AppendBytes(dst, tx.Data)
+
+	return appendAccessList(dst, tx.AccessList)
+}
@@ -0,0 +1,218 @@
+package tx
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sig-0/ethrlp"
+)
+
+// blobFields is the number of fields in an encoded (canonical) blob transaction
+const blobFields = 14
+
+// BlobTx is an (EIP-4844) blob transaction, encoded as
+// 0x03 || rlp([chainID, nonce, gasTipCap, gasFeeCap, gas, to, value, data, accessList,
+// blobFeeCap, blobHashes, yParity, r, s]).
+//
+// On the p2p network, blob transactions are sent along with their sidecar,
+// as 0x03 || rlp([tx, blobs, commitments, proofs]), or (EIP-7594)
+// 0x03 || rlp([tx, 1, blobs, commitments, cellProofs]).
+// The network form is encoded if the sidecar is set, and decoded
+// when the first element of the encoded list is itself a list
+type BlobTx struct {
+	ChainID    *big.Int       // chain the transaction is bound to
+	GasTipCap  *big.Int       // max priority fee per unit of gas
+	GasFeeCap  *big.Int       // max fee per unit of gas
+	Value      *big.Int       // transferred amount
+	BlobFeeCap *big.Int       // max fee per unit of blob gas
+	R          *big.Int       // signature R value
+	S          *big.Int       // signature S value
+	Sidecar    *BlobTxSidecar // blobs, commitments and proofs (network form only)
+	Data       []byte         // call data
+	AccessList AccessList     // pre-declared accessed accounts and storage slots
+	BlobHashes []Hash         // versioned hashes of the blob commitments
+	Nonce      uint64         // sender account nonce
+	Gas        uint64         // gas limit
+	To         Address        // recipient (blob transactions cannot create contracts)
+	YParity    byte           // signature recovery ID (0 or 1)
+}
+
+// Type returns the transaction type (BlobTxType)
+func (tx *BlobTx) Type() TxType {
+	return BlobTxType
+}
+
+// AppendRLP appends the RLP encoding of the transaction (without its type byte)
+// to dst, and returns the extended byte array. If the sidecar is set,
+// the network form is encoded. Nil big integers are encoded as zero.
+// The transaction needs to be valid (see Validate), otherwise AppendRLP panics
+func (tx *BlobTx) AppendRLP(dst []byte) []byte {
+	if tx.Sidecar == nil {
+		return tx.appendCanonical(dst)
+	}
+
+	return tx.Sidecar.appendWrapper(dst, tx.appendCanonical(nil))
+}
+
+// DecodeRLP decodes the given RLP value (without the type byte) into the transaction,
+// in either its canonical or network form. The sidecar is set only for the network form
+func (tx *BlobTx) DecodeRLP(v ethrlp.Value) error {
+	list, err := ethrlp.AsList(v)
+	if err != nil {
+		return fieldError("BlobTx", err)
+	}
+
+	first, err := list.At(0)
+	if err != nil || valueKind(first) != ethrlp.List {
+		tx.Sidecar = nil
+
+		return tx.decodeCanonical(list)
+	}
+
+	values, _ := list.GetValue().([]ethrlp.Value)
+
+	if err := tx.decodeCanonical(first); err != nil {
+		return err
+	}
+
+	tx.Sidecar = &BlobTxSidecar{}
+
+	if err := tx.Sidecar.decodeWrapper(values[1:]); err != nil {
+		return fieldError("BlobTx.Sidecar", err)
+	}
+
+	return nil
+}
+
+// SigningPayload returns the payload whose Keccak-256 hash is signed:
+// 0x03 || rlp([chainID, nonce, gasTipCap, gasFeeCap, gas, to, value, data, accessList,
+// blobFeeCap, blobHashes])
+func (tx *BlobTx) SigningPayload() ([]byte, error) {
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ethrlp.AppendList([]byte{byte(BlobTxType)}, tx.appendPayload), nil
+}
+
+// Validate makes sure none of the big integer fields are negative
+func (tx *BlobTx) Validate() error {
+	return errors.Join(
+		checkBigInt("BlobTx.ChainID", tx.ChainID),
+		checkBigInt("BlobTx.GasTipCap", tx.GasTipCap),
+		checkBigInt("BlobTx.GasFeeCap", tx.GasFeeCap),
+		checkBigInt("BlobTx.Value", tx.Value),
+		checkBigInt("BlobTx.BlobFeeCap", tx.BlobFeeCap),
+		checkBigInt("BlobTx.R", tx.R),
+		checkBigInt("BlobTx.S", tx.S),
+	)
+}
+
+// SetSignature sets the signature values of the transaction
+func (tx *BlobTx) SetSignature(yParity byte, r, s *big.Int) error {
+	if err := checkYParity(yParity); err != nil {
+		return err
+	}
+
+	if err := checkSignatureValues(r, s); err != nil {
+		return err
+	}
+
+	tx.YParity, tx.R, tx.S = yParity, new(big.Int).Set(r), new(big.Int).Set(s)
+
+	return nil
+}
+
+// appendCanonical appends the canonical RLP encoding of the transaction
+// (without its sidecar) to dst
+func (tx *BlobTx) appendCanonical(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = tx.appendPayload(dst)
+
+		return appendSignature(dst, tx.YParity, tx.R, tx.S)
+	})
+}
+
+// decodeCanonical decodes the given canonical RLP value into the transaction
+func (tx *BlobTx) decodeCanonical(v ethrlp.Value) error {
+	values, err := listFields(v, blobFields)
+	if err != nil {
+		return fieldError("BlobTx", err)
+	}
+
+	if tx.ChainID, err = decodeBigInt(values[0]); err != nil {
+		return fieldError("BlobTx.ChainID", err)
+	}
+
+	if tx.Nonce, err = decodeUint(values[1]); err != nil {
+		return fieldError("BlobTx.Nonce", err)
+	}
+
+	if tx.GasTipCap, err = decodeBigInt(values[2]); err != nil {
+		return fieldError("BlobTx.GasTipCap", err)
+	}
+
+	if tx.GasFeeCap, err = decodeBigInt(values[3]); err != nil {
+		return fieldError("BlobTx.GasFeeCap", err)
+	}
+
+	if tx.Gas, err = decodeUint(values[4]); err != nil {
+		return fieldError("BlobTx.Gas", err)
+	}
+
+	if tx.To, err = decodeAddress(values[5]); err != nil {
+		return fieldError("BlobTx.To", err)
+	}
+
+	if tx.Value, err = decodeBigInt(values[6]); err != nil {
+		return fieldError("BlobTx.Value", err)
+	}
+
+	if tx.Data, err = decodeBytes(values[7]); err != nil {
+		return fieldError("BlobTx.Data", err)
+	}
+
+	if tx.AccessList, err = decodeAccessList(values[8]); err != nil {
+		return fieldError("BlobTx.AccessList", err)
+	}
+
+	if tx.BlobFeeCap, err = decodeBigInt(values[9]); err != nil {
+		return fieldError("BlobTx.BlobFeeCap", err)
+	}
+
+	if tx.BlobHashes, err = ethrlp.DecodeSlice(values[10], decodeHash); err != nil {
+		return fieldError("BlobTx.BlobHashes", err)
+	}
+
+	if tx.YParity, err = decodeYParity(values[11]); err != nil {
+		return fieldError("BlobTx.YParity", err)
+	}
+
+	if tx.R, err = decodeBigInt(values[12]); err != nil {
+		return fieldError("BlobTx.R", err)
+	}
+
+	if tx.S, err = decodeBigInt(values[13]); err != nil {
+		return fieldError("BlobTx.S", err)
+	}
+
+	return nil
+}
+
+// appendPayload appends the unsigned transaction fields to dst
+func (tx *BlobTx) appendPayload(dst []byte) []byte {
+	dst = appendBigInt(dst, tx.ChainID)
+	dst = ethrlp.AppendUint64(dst, tx.Nonce)
+	dst = appendBigInt(dst, tx.GasTipCap)
+	dst = appendBigInt(dst, tx.GasFeeCap)
+	dst = ethrlp.AppendUint64(dst, tx.Gas)
+	dst = ethrlp.AppendBytes(dst, tx.To[:])
+	dst = appendBigInt(dst, tx.Value)
+	dst = ethrlp.AppendBytes(dst, tx.Data)
+	dst = appendAccessList(dst, tx.AccessList)
+	dst = appendBigInt(dst, tx.BlobFeeCap)
+
+	return ethrlp.AppendSlice(dst, tx.BlobHashes, func(dst []byte, hash Hash) []byte {
+		return ethrlp.AppendBytes(dst, hash[:])
+	})
+}
@@ -0,0 +1,31 @@
+package tx
+
+import (
+	"testing"
+)
+
+func BenchmarkBlobTx_EncodeWrapper(b *testing.B) {
+	tx := blobTx()
+	tx.Sidecar = blobSidecar(SidecarVersionBlobProofs, 6)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = requireBytes(b)(EncodeEnvelope(tx))
+	}
+}
+
+func BenchmarkBlobTx_DecodeWrapper(b *testing.B) {
+	tx := blobTx()
+	tx.Sidecar = blobSidecar(SidecarVersionBlobProofs, 6)
+
+	encoded := requireBytes(b)(EncodeEnvelope(tx))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeEnvelope(encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
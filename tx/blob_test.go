@@ -0,0 +1,249 @@
+package tx
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/sig-0/ethrlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBlobHash = Hash{0: 0x01}
+
+// blobTx returns a sample (signed) blob transaction, without a sidecar
+func blobTx() *BlobTx {
+	return &BlobTx{
+		ChainID:    big.NewInt(1),
+		Nonce:      0,
+		GasTipCap:  big.NewInt(1),
+		GasFeeCap:  big.NewInt(2),
+		Gas:        21000,
+		To:         testAddress,
+		Value:      big.NewInt(0),
+		Data:       []byte{},
+		AccessList: AccessList{},
+		BlobFeeCap: big.NewInt(3),
+		BlobHashes: []Hash{testBlobHash},
+		YParity:    0,
+		R:          big.NewInt(1),
+		S:          big.NewInt(2),
+	}
+}
+
+// blobSidecar returns a sample sidecar of the given version, with the given number of blobs
+func blobSidecar(version byte, blobs int) *BlobTxSidecar {
+	sidecar := &BlobTxSidecar{
+		Blobs:       make([]Blob, blobs),
+		Commitments: make([]Commitment, blobs),
+		Version:     version,
+	}
+
+	sidecar.Proofs = make([]Proof, blobs*sidecar.proofsPerBlob())
+
+	for index := range sidecar.Blobs {
+		sidecar.Blobs[index][0] = byte(index + 1)
+		sidecar.Blobs[index][BlobSize-1] = byte(index + 1)
+		sidecar.Commitments[index][0] = byte(index + 1)
+	}
+
+	for index := range sidecar.Proofs {
+		sidecar.Proofs[index][0] = byte(index + 1)
+	}
+
+	return sidecar
+}
+
+// appendFixedSize appends the given fixed size elements as an RLP list to dst
+func appendFixedSize[T any](dst []byte, items []T, bytes func(*T) []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		for index := range items {
+			dst = ethrlp.AppendBytes(dst, bytes(&items[index]))
+		}
+
+		return dst
+	})
+}
+
+func TestBlobTx_Canonical(t *testing.T) {
+	t.Parallel()
+
+	tx := blobTx()
+
+	encoded := requireBytes(t)(EncodeEnvelope(tx))
+	assert.Equal(
+		t,
+		hexToBytes(t, `03 f845 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0
+			03 e1 a0 0100000000000000000000000000000000000000000000000000000000000000
+			80 01 02`),
+		encoded,
+	)
+
+	assert.Equal(
+		t,
+		hexToBytes(t, `03 f842 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0
+			03 e1 a0 0100000000000000000000000000000000000000000000000000000000000000`),
+		requireBytes(t)(tx.SigningPayload()),
+	)
+
+	decoded, err := DecodeEnvelope(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, tx, decoded)
+}
+
+func TestBlobTx_Wrapper(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		sidecar *BlobTxSidecar
+		name    string
+	}{
+		{
+			blobSidecar(SidecarVersionBlobProofs, 2),
+			"blob proofs",
+		},
+		{
+			blobSidecar(SidecarVersionCellProofs, 2),
+			"cell proofs",
+		},
+		{
+			blobSidecar(SidecarVersionBlobProofs, 0),
+			"no blobs",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := blobTx()
+			tx.Sidecar = testCase.sidecar
+
+			// Construct the expected network wrapper with plain list encoding
+			expected := ethrlp.AppendList([]byte{byte(BlobTxType)}, func(dst []byte) []byte {
+				dst = append(dst, ethrlp.Encode(blobTx())...)
+
+				if testCase.sidecar.Version != SidecarVersionBlobProofs {
+					dst = ethrlp.AppendUint64(dst, uint64(testCase.sidecar.Version))
+				}
+
+				dst = appendFixedSize(dst, testCase.sidecar.Blobs, func(b *Blob) []byte { return b[:] })
+				dst = appendFixedSize(dst, testCase.sidecar.Commitments, func(c *Commitment) []byte { return c[:] })
+
+				return appendFixedSize(dst, testCase.sidecar.Proofs, func(p *Proof) []byte { return p[:] })
+			})
+
+			encoded := requireBytes(t)(EncodeEnvelope(tx))
+			assert.Equal(t, expected, encoded)
+
+			decoded, err := DecodeEnvelope(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, tx, decoded)
+
+			// Make sure the signing payload and hash do not include the sidecar
+			assert.Equal(
+				t,
+				requireBytes(t)(blobTx().SigningPayload()),
+				requireBytes(t)(tx.SigningPayload()),
+			)
+			assert.Equal(t, requireHash(t)(TxHash(blobTx())), requireHash(t)(TxHash(tx)))
+		})
+	}
+}
+
+func TestBlobTx_WrapperMemory(t *testing.T) {
+	t.Parallel()
+
+	tx := blobTx()
+	tx.Sidecar = blobSidecar(SidecarVersionBlobProofs, 1)
+
+	encoded := requireBytes(t)(EncodeEnvelope(tx))
+
+	decoded, err := DecodeEnvelope(encoded)
+	require.NoError(t, err)
+
+	// Make sure the decoded sidecar does not share the memory of the input
+	clear(encoded)
+
+	assert.Equal(t, tx, decoded)
+}
+
+func TestBlobTx_DecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	encodeWrapper := func(fields ...[]byte) []byte {
+		return ethrlp.AppendList([]byte{byte(BlobTxType)}, func(dst []byte) []byte {
+			dst = append(dst, ethrlp.Encode(blobTx())...)
+
+			for _, field := range fields {
+				dst = append(dst, field...)
+			}
+
+			return dst
+		})
+	}
+
+	var (
+		sidecar = blobSidecar(SidecarVersionBlobProofs, 1)
+
+		blobs       = appendFixedSize(nil, sidecar.Blobs, func(b *Blob) []byte { return b[:] })
+		commitments = appendFixedSize(nil, sidecar.Commitments, func(c *Commitment) []byte { return c[:] })
+		proofs      = appendFixedSize(nil, sidecar.Proofs, func(p *Proof) []byte { return p[:] })
+		empty       = ethrlp.EncodeArray(nil)
+	)
+
+	testTable := []struct {
+		err   error
+		name  string
+		input []byte
+	}{
+		{
+			ethrlp.ErrInvalidLength,
+			"missing sidecar fields",
+			encodeWrapper(blobs, commitments),
+		},
+		{
+			ErrInvalidSidecar,
+			"missing proofs",
+			encodeWrapper(blobs, commitments, empty),
+		},
+		{
+			ErrInvalidSidecar,
+			"missing cell proofs",
+			encodeWrapper(ethrlp.EncodeUint(1), blobs, commitments, proofs),
+		},
+		{
+			ErrInvalidSidecar,
+			"unsupported version",
+			encodeWrapper(ethrlp.EncodeUint(2), blobs, commitments, proofs),
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"invalid blob size",
+			encodeWrapper(ethrlp.EncodeArray([][]byte{ethrlp.EncodeBytes(make([]byte, BlobSize-1))}), commitments, proofs),
+		},
+		{
+			ethrlp.ErrExpectedString,
+			"list commitment",
+			encodeWrapper(blobs, ethrlp.EncodeArray([][]byte{empty}), proofs),
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"contract creation",
+			hexToBytes(t, `03 f1 01 80 01 02 825208 80 80 80 c0
+				03 e1 a0 0100000000000000000000000000000000000000000000000000000000000000
+				80 01 02`),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeEnvelope(testCase.input)
+			assert.ErrorIs(t, err, testCase.err)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package tx
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sig-0/ethrlp"
+)
+
+// dynamicFeeFields is the number of fields in an encoded dynamic fee transaction
+const dynamicFeeFields = 12
+
+// DynamicFeeTx is an (EIP-1559) dynamic fee transaction, encoded as
+// 0x02 || rlp([chainID, nonce, gasTipCap, gasFeeCap, gas, to, value, data, accessList, yParity, r, s])
+type DynamicFeeTx struct {
+	ChainID    *big.Int   // chain the transaction is bound to
+	GasTipCap  *big.Int   // max priority fee per unit of gas
+	GasFeeCap  *big.Int   // max fee per unit of gas
+	To         *Address   // recipient, nil for contract creation
+	Value      *big.Int   // transferred amount
+	R          *big.Int   // signature R value
+	S          *big.Int   // signature S value
+	Data       []byte     // call data, or contract init code
+	AccessList AccessList // pre-declared accessed accounts and storage slots
+	Nonce      uint64     // sender account nonce
+	Gas        uint64     // gas limit
+	YParity    byte       // signature recovery ID (0 or 1)
+}
+
+// Type returns the transaction type (DynamicFeeTxType)
+func (tx *DynamicFeeTx) Type() TxType {
+	return DynamicFeeTxType
+}
+
+// AppendRLP appends the RLP encoding of the transaction (without its type byte)
+// to dst, and returns the extended byte array.
+// The transaction needs to be valid (see Validate), otherwise AppendRLP panics.
+// Nil big integers are encoded as zero
+func (tx *DynamicFeeTx) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = tx.appendPayload(dst)
+
+		return appendSignature(dst, tx.YParity, tx.R, tx.S)
+	})
+}
+
+// DecodeRLP decodes the given RLP value (without the type byte) into the transaction
+func (tx *DynamicFeeTx) DecodeRLP(v ethrlp.Value) error {
+	values, err := listFields(v, dynamicFeeFields)
+	if err != nil {
+		return fieldError("DynamicFeeTx", err)
+	}
+
+	if tx.ChainID, err = decodeBigInt(values[0]); err != nil {
+		return fieldError("DynamicFeeTx.ChainID", err)
+	}
+
+	if tx.Nonce, err = decodeUint(values[1]); err != nil {
+		return fieldError("DynamicFeeTx.Nonce", err)
+	}
+
+	if tx.GasTipCap, err = decodeBigInt(values[2]); err != nil {
+		return fieldError("DynamicFeeTx.GasTipCap", err)
+	}
+
+	if tx.GasFeeCap, err = decodeBigInt(values[3]); err != nil {
+		return fieldError("DynamicFeeTx.GasFeeCap", err)
+	}
+
+	if tx.Gas, err = decodeUint(values[4]); err != nil {
+		return fieldError("DynamicFeeTx.Gas", err)
+	}
+
+	if tx.To, err = decodeRecipient(values[5]); err != nil {
+		return fieldError("DynamicFeeTx.To", err)
+	}
+
+	if tx.Value, err = decodeBigInt(values[6]); err != nil {
+		return fieldError("DynamicFeeTx.Value", err)
+	}
+
+	if tx.Data, err = decodeBytes(values[7]); err != nil {
+		return fieldError("DynamicFeeTx.Data", err)
+	}
+
+	if tx.AccessList, err = decodeAccessList(values[8]); err != nil {
+		return fieldError("DynamicFeeTx.AccessList", err)
+	}
+
+	if tx.YParity, err = decodeYParity(values[9]); err != nil {
+		return fieldError("DynamicFeeTx.YParity", err)
+	}
+
+	if tx.R, err = decodeBigInt(values[10]); err != nil {
+		return fieldError("DynamicFeeTx.R", err)
+	}
+
+	if tx.S, err = decodeBigInt(values[11]); err != nil {
+		return fieldError("DynamicFeeTx.S", err)
+	}
+
+	return nil
+}
+
+// SigningPayload returns the payload whose Keccak-256 hash is signed:
+// 0x02 || rlp([chainID, nonce, gasTipCap, gasFeeCap, gas, to, value, data, accessList])
+func (tx *DynamicFeeTx) SigningPayload() ([]byte, error) {
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ethrlp.AppendList([]byte{byte(DynamicFeeTxType)}, tx.appendPayload), nil
+}
+
+// Validate makes sure none of the big integer fields are negative
+func (tx *DynamicFeeTx) Validate() error {
+	return errors.Join(
+		checkBigInt("DynamicFeeTx.ChainID", tx.ChainID),
+		checkBigInt("DynamicFeeTx.GasTipCap", tx.GasTipCap),
+		checkBigInt("DynamicFeeTx.GasFeeCap", tx.GasFeeCap),
+		checkBigInt("DynamicFeeTx.Value", tx.Value),
+		checkBigInt("DynamicFeeTx.R", tx.R),
+		checkBigInt("DynamicFeeTx.S", tx.S),
+	)
+}
+
+// SetSignature sets the signature values of the transaction
+func (tx *DynamicFeeTx) SetSignature(yParity byte, r, s *big.Int) error {
+	if err := checkYParity(yParity); err != nil {
+		return err
+	}
+
+	if err := checkSignatureValues(r, s); err != nil {
+		return err
+	}
+
+	tx.YParity, tx.R, tx.S = yParity, new(big.Int).Set(r), new(big.Int).Set(s)
+
+	return nil
+}
+
+// appendPayload appends the unsigned transaction fields to dst
+func (tx *DynamicFeeTx) appendPayload(dst []byte) []byte {
+	dst = appendBigInt(dst, tx.ChainID)
+	dst = ethrlp.AppendUint64(dst, tx.Nonce)
+	dst = appendBigInt(dst, tx.GasTipCap)
+	dst = appendBigInt(dst, tx.GasFeeCap)
+	dst = ethrlp.AppendUint64(dst, tx.Gas)
+	dst = appendRecipient(dst, tx.To)
+	dst = appendBigInt(dst, tx.Value)
+	dst = ethrlp.AppendBytes(dst, tx.Data)
+
+	return appendAccessList(dst, tx.AccessList)
+}
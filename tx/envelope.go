@@ -0,0 +1,196 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/sig-0/ethrlp"
+)
+
+// TxType is the EIP-2718 transaction type, which prefixes the encoding of typed transactions
+type TxType byte
+
+const (
+	LegacyTxType     TxType = 0x00
+	AccessListTxType TxType = 0x01
+	DynamicFeeTxType TxType = 0x02
+	BlobTxType       TxType = 0x03
+	SetCodeTxType    TxType = 0x04
+)
+
+// maxTxType is the largest valid EIP-2718 transaction type.
+// Larger first bytes are the start of an RLP item
+const maxTxType = 0x7f
+
+// TxData is implemented by all transaction types
+type TxData interface {
+	ethrlp.RLPEncoder
+	ethrlp.RLPDecoder
+
+	// Type returns the EIP-2718 type of the transaction
+	Type() TxType
+
+	// Validate makes sure the transaction fields can be encoded
+	// (none of the big integer fields are negative)
+	Validate() error
+}
+
+// newTxData creates an empty transaction of the given type
+func newTxData(txType TxType) (TxData, error) {
+	switch txType {
+	case LegacyTxType:
+		return &LegacyTx{}, nil
+	case AccessListTxType:
+		return &AccessListTx{}, nil
+	case DynamicFeeTxType:
+		return &DynamicFeeTx{}, nil
+	case BlobTxType:
+		return &BlobTx{}, nil
+	case SetCodeTxType:
+		return &SetCodeTx{}, nil
+	default:
+		return nil, fmt.Errorf("%w: 0x%02x", ErrUnsupportedTxType, byte(txType))
+	}
+}
+
+// EncodeEnvelope returns the (EIP-2718) envelope encoding of the given transaction.
+// Legacy transactions are encoded as an RLP list, and typed transactions
+// as their type byte, followed by the RLP encoding of their fields.
+// The transaction is validated before it is encoded
+func EncodeEnvelope(tx TxData) ([]byte, error) {
+	return AppendEnvelope(nil, tx)
+}
+
+// AppendEnvelope appends the envelope encoding of the given transaction to dst,
+// and returns the extended byte array.
+// The transaction is validated before it is encoded
+func AppendEnvelope(dst []byte, tx TxData) ([]byte, error) {
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	return appendEnvelope(dst, tx), nil
+}
+
+// appendEnvelope appends the envelope encoding of the given (valid) transaction to dst
+func appendEnvelope(dst []byte, tx TxData) []byte {
+	if tx.Type() != LegacyTxType {
+		dst = append(dst, byte(tx.Type()))
+	}
+
+	return tx.AppendRLP(dst)
+}
+
+// DecodeEnvelope decodes the given envelope encoding into a transaction,
+// dispatching on its first byte. A first byte in the RLP list range (0xc0 and up)
+// marks a legacy transaction, and a first byte up to 0x7f a typed transaction.
+// The payload is decoded with ethrlp.DefaultDecodeOptions, so non-canonical
+// encodings (which would hash differently than they decode) are rejected
+func DecodeEnvelope(input []byte) (TxData, error) {
+	if len(input) == 0 {
+		return nil, ethrlp.ErrEmptyInput
+	}
+
+	var (
+		txType  = LegacyTxType
+		payload = input
+	)
+
+	switch {
+	case input[0] >= 0xc0:
+		// Legacy transaction list
+	case input[0] <= maxTxType:
+		txType, payload = TxType(input[0]), input[1:]
+
+		if txType == LegacyTxType {
+			return nil, fmt.Errorf("%w: 0x00 prefix", ErrInvalidTxType)
+		}
+	default:
+		return nil, fmt.Errorf("%w: 0x%02x", ErrInvalidTxType, input[0])
+	}
+
+	tx, err := newTxData(txType)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := ethrlp.DefaultDecodeOptions.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.DecodeRLP(value); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// TxHash returns the hash of the given transaction, which is
+// the Keccak-256 hash of its envelope encoding.
+// The sidecar of blob transactions is not part of the hash
+func TxHash(tx TxData) (Hash, error) {
+	if err := tx.Validate(); err != nil {
+		return Hash{}, err
+	}
+
+	if blobTx, ok := tx.(*BlobTx); ok && blobTx.Sidecar != nil {
+		return Hash(ethrlp.HashOf(blobTx.appendCanonical([]byte{byte(BlobTxType)}))), nil
+	}
+
+	return Hash(ethrlp.HashOf(appendEnvelope(nil, tx))), nil
+}
+
+// EncodeBodyTxs returns the block body encoding of the given transactions,
+// as an RLP list of AppendBodyTx items.
+// The transactions are validated before they are encoded
+func EncodeBodyTxs(txs []TxData) ([]byte, error) {
+	for index, tx := range txs {
+		if err := tx.Validate(); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", index, err)
+		}
+	}
+
+	return ethrlp.EncodeSlice(txs, AppendBodyTx), nil
+}
+
+// AppendBodyTx appends the block body encoding of the given transaction to dst,
+// and returns the extended byte array. Within block bodies (and p2p transaction lists),
+// legacy transactions are kept as RLP lists, while the envelope of
+// typed transactions is wrapped in an RLP byte string.
+// AppendBodyTx can be used as an encoding function for ethrlp.AppendSlice.
+// The transaction needs to be valid (see TxData.Validate), otherwise AppendBodyTx
+// panics. EncodeBodyTxs validates the transactions before encoding them
+func AppendBodyTx(dst []byte, tx TxData) []byte {
+	if tx.Type() == LegacyTxType {
+		return tx.AppendRLP(dst)
+	}
+
+	return ethrlp.AppendBytes(dst, appendEnvelope(nil, tx))
+}
+
+// DecodeBodyTx decodes the given block body transaction value,
+// which is either a legacy transaction list, or a byte string holding
+// the envelope of a typed transaction.
+// DecodeBodyTx can be used as a decoding function for ethrlp.DecodeSlice
+func DecodeBodyTx(v ethrlp.Value) (TxData, error) {
+	if valueKind(v) == ethrlp.List {
+		tx := &LegacyTx{}
+
+		if err := tx.DecodeRLP(v); err != nil {
+			return nil, err
+		}
+
+		return tx, nil
+	}
+
+	envelope, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(envelope.Bytes()) > 0 && envelope.Bytes()[0] >= 0xc0 {
+		return nil, fmt.Errorf("%w: legacy transaction wrapped in a byte string", ErrInvalidTxType)
+	}
+
+	return DecodeEnvelope(envelope.Bytes())
+}
@@ -0,0 +1,341 @@
+package tx
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/sig-0/ethrlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAddress = Address(
+		[]byte(strings.Repeat("\x35", 20)),
+	)
+
+	testStorageKey = Hash{31: 0x01}
+)
+
+// dynamicFeeTx returns a sample (signed) dynamic fee transaction
+func dynamicFeeTx() *DynamicFeeTx {
+	to := testAddress
+
+	return &DynamicFeeTx{
+		ChainID:    big.NewInt(1),
+		Nonce:      0,
+		GasTipCap:  big.NewInt(1),
+		GasFeeCap:  big.NewInt(2),
+		Gas:        21000,
+		To:         &to,
+		Value:      big.NewInt(0),
+		Data:       []byte{},
+		AccessList: AccessList{},
+		YParity:    0,
+		R:          big.NewInt(1),
+		S:          big.NewInt(2),
+	}
+}
+
+// accessListTx returns a sample (signed) access list transaction,
+// which creates a contract
+func accessListTx() *AccessListTx {
+	return &AccessListTx{
+		ChainID:  big.NewInt(1),
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		To:       nil,
+		Value:    big.NewInt(0),
+		Data:     []byte{},
+		AccessList: AccessList{
+			{
+				Address:     testAddress,
+				StorageKeys: []Hash{testStorageKey},
+			},
+		},
+		YParity: 1,
+		R:       big.NewInt(1),
+		S:       big.NewInt(1),
+	}
+}
+
+func TestEnvelope_Encode(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		tx       TxData
+		name     string
+		expected string
+	}{
+		{
+			eip155Tx(t),
+			"legacy transaction",
+			eip155SignedTx,
+		},
+		{
+			accessListTx(),
+			"access list transaction",
+			`01 f846 01 01 01 825208 80 80 80
+				f838 f7 94 3535353535353535353535353535353535353535
+					e1 a0 0000000000000000000000000000000000000000000000000000000000000001
+				01 01 01`,
+		},
+		{
+			dynamicFeeTx(),
+			"dynamic fee transaction",
+			`02 e2 01 80 01 02 825208 94 3535353535353535353535353535353535353535
+				80 80 c0 80 01 02`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded := requireBytes(t)(EncodeEnvelope(testCase.tx))
+			assert.Equal(t, hexToBytes(t, testCase.expected), encoded)
+
+			// Make sure the envelope is decoded into the same transaction type
+			decoded, err := DecodeEnvelope(encoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.tx, decoded)
+
+			// Make sure the transaction hash covers the envelope
+			assert.Equal(t, Hash(ethrlp.HashOf(encoded)), requireHash(t)(TxHash(decoded)))
+		})
+	}
+}
+
+func TestEnvelope_SigningPayload(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		hexToBytes(t, `01 f843 01 01 01 825208 80 80 80
+			f838 f7 94 3535353535353535353535353535353535353535
+				e1 a0 0000000000000000000000000000000000000000000000000000000000000001`),
+		requireBytes(t)(accessListTx().SigningPayload()),
+	)
+
+	assert.Equal(
+		t,
+		hexToBytes(t, "02 df 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0"),
+		requireBytes(t)(dynamicFeeTx().SigningPayload()),
+	)
+}
+
+func TestEnvelope_Signature(t *testing.T) {
+	t.Parallel()
+
+	tx := dynamicFeeTx()
+
+	require.NoError(t, tx.SetSignature(1, big.NewInt(3), big.NewInt(4)))
+
+	assert.Equal(t, byte(1), tx.YParity)
+	assert.Equal(t, big.NewInt(3), tx.R)
+	assert.Equal(t, big.NewInt(4), tx.S)
+
+	assert.ErrorIs(t, tx.SetSignature(2, tx.R, tx.S), ErrInvalidRecoveryID)
+	assert.ErrorIs(t, accessListTx().SetSignature(27, tx.R, tx.S), ErrInvalidRecoveryID)
+
+	// Make sure missing signature values are rejected, and the previous ones are kept
+	assert.ErrorIs(t, tx.SetSignature(0, nil, tx.S), ErrMissingSignature)
+	assert.ErrorIs(t, blobTx().SetSignature(0, tx.R, nil), ErrMissingSignature)
+	assert.ErrorIs(t, setCodeTx().SetSignature(0, nil, nil), ErrMissingSignature)
+
+	assert.Equal(t, byte(1), tx.YParity)
+	assert.Equal(t, big.NewInt(3), tx.R)
+}
+
+func TestEnvelope_Validate(t *testing.T) {
+	t.Parallel()
+
+	negative := big.NewInt(-1)
+
+	legacy := eip155Tx(t)
+	legacy.Value = negative
+
+	accessList := accessListTx()
+	accessList.GasPrice = negative
+
+	dynamicFee := dynamicFeeTx()
+	dynamicFee.GasFeeCap = negative
+
+	blob := blobTx()
+	blob.BlobFeeCap = negative
+
+	setCode := setCodeTx()
+	setCode.Authorizations[0].ChainID = negative
+
+	for _, tx := range []TxData{legacy, accessList, dynamicFee, blob, setCode} {
+		// Make sure invalid transactions are rejected with an error, instead of a panic
+		assert.ErrorIs(t, tx.Validate(), ethrlp.ErrNegativeBigInt)
+
+		_, err := EncodeEnvelope(tx)
+		assert.ErrorIs(t, err, ethrlp.ErrNegativeBigInt)
+
+		_, err = TxHash(tx)
+		assert.ErrorIs(t, err, ethrlp.ErrNegativeBigInt)
+
+		_, err = EncodeBodyTxs([]TxData{eip155Tx(t), tx})
+		assert.ErrorIs(t, err, ethrlp.ErrNegativeBigInt)
+	}
+
+	_, err := legacy.SigningPayload(big.NewInt(1))
+	assert.ErrorIs(t, err, ethrlp.ErrNegativeBigInt)
+
+	_, err = eip155Tx(t).SigningPayload(negative)
+	assert.ErrorIs(t, err, ethrlp.ErrNegativeBigInt)
+
+	_, err = dynamicFee.SigningPayload()
+	assert.ErrorIs(t, err, ethrlp.ErrNegativeBigInt)
+
+	_, err = setCode.Authorizations[0].SigningPayload()
+	assert.ErrorIs(t, err, ethrlp.ErrNegativeBigInt)
+}
+
+func TestEnvelope_DecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		err   error
+		name  string
+		input string
+	}{
+		{
+			ethrlp.ErrEmptyInput,
+			"empty input",
+			"",
+		},
+		{
+			ErrInvalidTxType,
+			"RLP string",
+			"8180",
+		},
+		{
+			ErrInvalidTxType,
+			"zero type byte",
+			"00c0",
+		},
+		{
+			ErrUnsupportedTxType,
+			"unknown type byte",
+			"7fc0",
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"missing fields",
+			"02c3018080",
+		},
+		{
+			ErrInvalidRecoveryID,
+			"invalid y parity",
+			"02 e2 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0 02 01 02",
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"invalid storage key size",
+			`01 e5 01 01 01 825208 80 80 80
+				d8 d7 94 3535353535353535353535353535353535353535 c1 01
+				01 01 01`,
+		},
+		{
+			ethrlp.ErrTrailingBytes,
+			"trailing bytes",
+			"02 e2 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0 80 01 02 00",
+		},
+		{
+			ethrlp.ErrCanonSize,
+			"non-canonical legacy nonce",
+			`f86d 8109 8504a817c800 825208 943535353535353535353535353535353535353535 880de0b6b3a7640000 80 25
+				a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276
+				a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83`,
+		},
+		{
+			ethrlp.ErrNonCanonicalLength,
+			"non-canonical typed list header",
+			"02 f822 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0 80 01 02",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeEnvelope(hexToBytes(t, testCase.input))
+			assert.ErrorIs(t, err, testCase.err)
+		})
+	}
+
+	t.Run("input over the size limit", func(t *testing.T) {
+		t.Parallel()
+
+		input := ethrlp.EncodeBytes(make([]byte, ethrlp.DefaultDecodeOptions.MaxInputSize))
+
+		_, err := DecodeEnvelope(append([]byte{byte(DynamicFeeTxType)}, input...))
+		assert.ErrorIs(t, err, ethrlp.ErrMaxInputSize)
+	})
+}
+
+func TestEnvelope_BodyTx(t *testing.T) {
+	t.Parallel()
+
+	txs := []TxData{
+		eip155Tx(t),
+		accessListTx(),
+		dynamicFeeTx(),
+	}
+
+	encoded := requireBytes(t)(EncodeBodyTxs(txs))
+
+	// Make sure legacy transactions are kept as lists,
+	// and typed transaction envelopes are wrapped in byte strings
+	value, err := ethrlp.DecodeBytes(encoded)
+	require.NoError(t, err)
+
+	legacy, err := ethrlp.Get(value, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, ethrlp.List, legacy.GetType())
+
+	for index := 1; index < len(txs); index++ {
+		envelope, err := ethrlp.GetBytes(value, index)
+		require.NoError(t, err)
+
+		assert.Equal(t, requireBytes(t)(EncodeEnvelope(txs[index])), envelope.Bytes())
+	}
+
+	// Make sure the transactions are decoded from both decoded and raw values
+	decoded, err := ethrlp.DecodeSlice(value, DecodeBodyTx)
+	require.NoError(t, err)
+
+	assert.Equal(t, txs, decoded)
+
+	raw, err := ethrlp.DecodeRaw(encoded)
+	require.NoError(t, err)
+
+	decoded, err = ethrlp.DecodeSlice(raw, DecodeBodyTx)
+	require.NoError(t, err)
+
+	assert.Equal(t, txs, decoded)
+}
+
+func TestEnvelope_BodyTxInvalid(t *testing.T) {
+	t.Parallel()
+
+	// Legacy transaction wrapped in a byte string
+	legacy := requireBytes(t)(EncodeEnvelope(eip155Tx(t)))
+
+	_, err := DecodeBodyTx(ethrlp.NewBytes(legacy))
+	assert.ErrorIs(t, err, ErrInvalidTxType)
+
+	// Typed transaction without its type byte
+	raw, err := ethrlp.DecodeRaw(requireBytes(t)(EncodeEnvelope(dynamicFeeTx()))[1:])
+	require.NoError(t, err)
+
+	_, err = DecodeBodyTx(raw)
+	assert.ErrorIs(t, err, ethrlp.ErrInvalidLength)
+}
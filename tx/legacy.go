@@ -0,0 +1,241 @@
+package tx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sig-0/ethrlp"
+)
+
+const (
+	// legacyFields is the number of fields in an encoded legacy transaction
+	legacyFields = 9
+
+	// unprotectedV is the V base of (pre-EIP-155) signatures without a chain ID
+	unprotectedV = 27
+
+	// protectedV is the V base of EIP-155 signatures, which is offset by 2 * chain ID
+	protectedV = 35
+)
+
+// LegacyTx is a legacy (untyped) transaction, encoded as the RLP list
+// [nonce, gasPrice, gas, to, value, data, v, r, s].
+//
+// Before EIP-155, V is 27 or 28 (the recovery ID, offset by 27).
+// With EIP-155, V is the recovery ID offset by 35 + 2 * chain ID,
+// which binds the signature to a single chain
+type LegacyTx struct {
+	GasPrice *big.Int // price per unit of gas
+	To       *Address // recipient, nil for contract creation
+	Value    *big.Int // transferred amount
+	V        *big.Int // signature V value
+	R        *big.Int // signature R value
+	S        *big.Int // signature S value
+	Data     []byte   // call data, or contract init code
+	Nonce    uint64   // sender account nonce
+	Gas      uint64   // gas limit
+}
+
+// Type returns the transaction type (LegacyTxType)
+func (tx *LegacyTx) Type() TxType {
+	return LegacyTxType
+}
+
+// AppendRLP appends the RLP encoding of the transaction to dst,
+// and returns the extended byte array.
+// Nil big integers are encoded as zero.
+// The transaction needs to be valid (see Validate), otherwise AppendRLP panics
+func (tx *LegacyTx) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = tx.appendPayload(dst)
+		dst = appendBigInt(dst, tx.V)
+		dst = appendBigInt(dst, tx.R)
+
+		return appendBigInt(dst, tx.S)
+	})
+}
+
+// DecodeRLP decodes the given RLP value into the transaction
+func (tx *LegacyTx) DecodeRLP(v ethrlp.Value) error {
+	values, err := listFields(v, legacyFields)
+	if err != nil {
+		return fieldError("LegacyTx", err)
+	}
+
+	if tx.Nonce, err = decodeUint(values[0]); err != nil {
+		return fieldError("LegacyTx.Nonce", err)
+	}
+
+	if tx.GasPrice, err = decodeBigInt(values[1]); err != nil {
+		return fieldError("LegacyTx.GasPrice", err)
+	}
+
+	if tx.Gas, err = decodeUint(values[2]); err != nil {
+		return fieldError("LegacyTx.Gas", err)
+	}
+
+	if tx.To, err = decodeRecipient(values[3]); err != nil {
+		return fieldError("LegacyTx.To", err)
+	}
+
+	if tx.Value, err = decodeBigInt(values[4]); err != nil {
+		return fieldError("LegacyTx.Value", err)
+	}
+
+	if tx.Data, err = decodeBytes(values[5]); err != nil {
+		return fieldError("LegacyTx.Data", err)
+	}
+
+	if tx.V, err = decodeBigInt(values[6]); err != nil {
+		return fieldError("LegacyTx.V", err)
+	}
+
+	if tx.R, err = decodeBigInt(values[7]); err != nil {
+		return fieldError("LegacyTx.R", err)
+	}
+
+	if tx.S, err = decodeBigInt(values[8]); err != nil {
+		return fieldError("LegacyTx.S", err)
+	}
+
+	return nil
+}
+
+// SigningPayload returns the RLP payload whose Keccak-256 hash is signed.
+// For a nil or zero chain ID, this is the pre-EIP-155 payload
+// [nonce, gasPrice, gas, to, value, data]. Otherwise, it is the
+// EIP-155 payload [nonce, gasPrice, gas, to, value, data, chainID, 0, 0]
+func (tx *LegacyTx) SigningPayload(chainID *big.Int) ([]byte, error) {
+	if err := errors.Join(tx.Validate(), checkBigInt("chain ID", chainID)); err != nil {
+		return nil, err
+	}
+
+	return ethrlp.AppendList(nil, func(dst []byte) []byte {
+		dst = tx.appendPayload(dst)
+
+		if chainID == nil || chainID.Sign() == 0 {
+			return dst
+		}
+
+		dst = ethrlp.AppendBigInt(dst, chainID)
+		dst = ethrlp.AppendUint64(dst, 0)
+
+		return ethrlp.AppendUint64(dst, 0)
+	}), nil
+}
+
+// Validate makes sure none of the big integer fields are negative
+func (tx *LegacyTx) Validate() error {
+	return errors.Join(
+		checkBigInt("LegacyTx.GasPrice", tx.GasPrice),
+		checkBigInt("LegacyTx.Value", tx.Value),
+		checkBigInt("LegacyTx.V", tx.V),
+		checkBigInt("LegacyTx.R", tx.R),
+		checkBigInt("LegacyTx.S", tx.S),
+	)
+}
+
+// Protected returns true if the transaction signature is bound
+// to a chain ID (EIP-155), based on its V value
+func (tx *LegacyTx) Protected() bool {
+	if tx.V == nil {
+		return false
+	}
+
+	if !tx.V.IsUint64() {
+		return true
+	}
+
+	v := tx.V.Uint64()
+
+	return v != unprotectedV && v != unprotectedV+1
+}
+
+// ChainID returns the chain ID the transaction signature is bound to,
+// derived from its V value. Unprotected (pre-EIP-155) transactions
+// have no chain ID, in which case nil is returned
+func (tx *LegacyTx) ChainID() (*big.Int, error) {
+	if err := tx.checkV(); err != nil {
+		return nil, err
+	}
+
+	if !tx.Protected() {
+		return nil, nil
+	}
+
+	// chainID = (V - 35) / 2
+	chainID := new(big.Int).Sub(tx.V, big.NewInt(protectedV))
+
+	return chainID.Rsh(chainID, 1), nil
+}
+
+// RecoveryID returns the signature recovery ID (0 or 1),
+// derived from the V value of the transaction
+func (tx *LegacyTx) RecoveryID() (byte, error) {
+	if err := tx.checkV(); err != nil {
+		return 0, err
+	}
+
+	if !tx.Protected() {
+		return byte(tx.V.Uint64() - unprotectedV), nil
+	}
+
+	// V - 35 = 2 * chainID + recoveryID
+	return byte(tx.V.Bit(0) ^ 1), nil
+}
+
+// SetSignature sets the signature values of the transaction.
+// For a nil or zero chain ID, V is set as a pre-EIP-155 value (27 + recovery ID).
+// Otherwise, it is set as an EIP-155 value (35 + 2 * chain ID + recovery ID).
+// Missing (nil) R or S values are rejected with ErrMissingSignature
+func (tx *LegacyTx) SetSignature(chainID *big.Int, recoveryID byte, r, s *big.Int) error {
+	if err := checkYParity(recoveryID); err != nil {
+		return err
+	}
+
+	if err := checkSignatureValues(r, s); err != nil {
+		return err
+	}
+
+	if chainID == nil || chainID.Sign() == 0 {
+		tx.V = big.NewInt(int64(unprotectedV + recoveryID))
+	} else {
+		tx.V = new(big.Int).Lsh(chainID, 1)
+		tx.V.Add(tx.V, big.NewInt(int64(protectedV+recoveryID)))
+	}
+
+	tx.R = new(big.Int).Set(r)
+	tx.S = new(big.Int).Set(s)
+
+	return nil
+}
+
+// checkV makes sure the V value is either a pre-EIP-155,
+// or an EIP-155 signature value
+func (tx *LegacyTx) checkV() error {
+	if tx.V == nil {
+		return fmt.Errorf("%w: missing", ErrInvalidV)
+	}
+
+	if !tx.Protected() {
+		return nil
+	}
+
+	if tx.V.Cmp(big.NewInt(protectedV)) < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidV, tx.V)
+	}
+
+	return nil
+}
+
+// appendPayload appends the unsigned transaction fields to dst
+func (tx *LegacyTx) appendPayload(dst []byte) []byte {
+	dst = ethrlp.AppendUint64(dst, tx.Nonce)
+	dst = appendBigInt(dst, tx.GasPrice)
+	dst = ethrlp.AppendUint64(dst, tx.Gas)
+	dst = appendRecipient(dst, tx.To)
+	dst = appendBigInt(dst, tx.Value)
+
+	return ethrlp.AppendBytes(dst, tx.Data)
+}
@@ -0,0 +1,351 @@
+package tx
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/sig-0/ethrlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hexToBytes(t *testing.T, input string) []byte {
+	t.Helper()
+
+	r := strings.NewReplacer("\t", "", " ", "", "\n", "")
+
+	data, err := hex.DecodeString(r.Replace(input))
+	require.NoError(t, err)
+
+	return data
+}
+
+// requireBytes returns a function that fails the test on a non-nil error,
+// and otherwise returns the given bytes
+func requireBytes(t testing.TB) func([]byte, error) []byte {
+	t.Helper()
+
+	return func(b []byte, err error) []byte {
+		t.Helper()
+
+		require.NoError(t, err)
+
+		return b
+	}
+}
+
+// requireHash returns a function that fails the test on a non-nil error,
+// and otherwise returns the given hash
+func requireHash(t testing.TB) func(Hash, error) Hash {
+	t.Helper()
+
+	return func(h Hash, err error) Hash {
+		t.Helper()
+
+		require.NoError(t, err)
+
+		return h
+	}
+}
+
+func hexToBigInt(t *testing.T, input string) *big.Int {
+	t.Helper()
+
+	return new(big.Int).SetBytes(hexToBytes(t, input))
+}
+
+// eip155Tx returns the (signed) example transaction from the EIP-155 specification
+func eip155Tx(t *testing.T) *LegacyTx {
+	t.Helper()
+
+	to := Address(hexToBytes(t, "3535353535353535353535353535353535353535"))
+
+	return &LegacyTx{
+		Nonce:    9,
+		GasPrice: big.NewInt(20_000_000_000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1_000_000_000_000_000_000),
+		Data:     []byte{},
+		V:        big.NewInt(37),
+		R:        hexToBigInt(t, "28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"),
+		S:        hexToBigInt(t, "67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"),
+	}
+}
+
+const (
+	eip155SigningPayload = "ec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080"
+
+	eip155SignedTx = `f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025
+		a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276
+		a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83`
+)
+
+func TestLegacyTx_EIP155(t *testing.T) {
+	t.Parallel()
+
+	tx := eip155Tx(t)
+
+	// Make sure the signing payload (and its hash) matches the specification
+	assert.Equal(t, hexToBytes(t, eip155SigningPayload), requireBytes(t)(tx.SigningPayload(big.NewInt(1))))
+
+	signingHash := ethrlp.HashOf(requireBytes(t)(tx.SigningPayload(big.NewInt(1))))
+	assert.Equal(
+		t,
+		hexToBytes(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"),
+		signingHash[:],
+	)
+
+	// Make sure the signed transaction matches the specification
+	encoded := ethrlp.Encode(tx)
+	assert.Equal(t, hexToBytes(t, eip155SignedTx), encoded)
+
+	var decoded LegacyTx
+	require.NoError(t, ethrlp.Decode(encoded, &decoded))
+
+	assert.Equal(t, tx, &decoded)
+
+	// Make sure the signature values are interpreted correctly
+	assert.True(t, decoded.Protected())
+
+	chainID, err := decoded.ChainID()
+	require.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(1), chainID)
+
+	recoveryID, err := decoded.RecoveryID()
+	require.NoError(t, err)
+
+	assert.Equal(t, byte(0), recoveryID)
+}
+
+func TestLegacyTx_Mainnet(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		chainID    *big.Int
+		name       string
+		input      string
+		hash       string
+		nonce      uint64
+		recoveryID byte
+	}{
+		{
+			nil,
+			"pre-EIP-155 (first transaction, block 46147)",
+			`f867 80 862d79883d2000 825208 945df9b87991262f6ba471f09758cde1c0fc1de734 827a69 80 1c
+				a088ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0
+				a045e0aff800961cfce805daef7016b9b675c137a6a41a548f7b60a3484c06a33a`,
+			"5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+			0,
+			1,
+		},
+		{
+			big.NewInt(1),
+			"EIP-155 (block 6139707)",
+			`f871 15 8504a817c800 82c350 94f02c1c8e6114b1dbe8937a39260b5b0a374432bb 870f3dbb76162000 8668656c6c6f21 25
+				a01b5e176d927f8e9ab405058b2d2457392da3e20f328b16ddabcebc33eaac5fea
+				a04ba69724e8f69de52f0125ad8b3c5c2cef33019bac3249e2c0a2192766d1721c`,
+			"88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+			21,
+			0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := hexToBytes(t, testCase.input)
+
+			decoded, err := DecodeEnvelope(input)
+			require.NoError(t, err)
+
+			tx, ok := decoded.(*LegacyTx)
+			require.True(t, ok)
+
+			assert.Equal(t, testCase.nonce, tx.Nonce)
+			assert.Equal(t, testCase.chainID != nil, tx.Protected())
+
+			// Make sure the transaction hash matches the one on chain
+			assert.Equal(t, Hash(hexToBytes(t, testCase.hash)), requireHash(t)(TxHash(tx)))
+
+			// Make sure the transaction is re-encoded byte for byte
+			assert.Equal(t, input, requireBytes(t)(EncodeEnvelope(tx)))
+
+			chainID, err := tx.ChainID()
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.chainID, chainID)
+
+			recoveryID, err := tx.RecoveryID()
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.recoveryID, recoveryID)
+		})
+	}
+}
+
+func TestLegacyTx_SigningPayload(t *testing.T) {
+	t.Parallel()
+
+	tx := eip155Tx(t)
+
+	// The pre-EIP-155 payload omits the [chainID, 0, 0] suffix
+	unprotected := "e9098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080"
+
+	assert.Equal(t, hexToBytes(t, unprotected), requireBytes(t)(tx.SigningPayload(nil)))
+	assert.Equal(t, hexToBytes(t, unprotected), requireBytes(t)(tx.SigningPayload(big.NewInt(0))))
+
+	// Contract creations encode an empty recipient
+	tx.To = nil
+	tx.Data = []byte{0x60, 0x00}
+
+	assert.Equal(
+		t,
+		hexToBytes(t, "da098504a817c80082520880880de0b6b3a7640000826000018080"),
+		requireBytes(t)(tx.SigningPayload(big.NewInt(1))),
+	)
+}
+
+func TestLegacyTx_Signature(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		chainID    *big.Int
+		name       string
+		v          int64
+		recoveryID byte
+	}{
+		{
+			nil,
+			"pre-EIP-155, recovery ID 0",
+			27,
+			0,
+		},
+		{
+			nil,
+			"pre-EIP-155, recovery ID 1",
+			28,
+			1,
+		},
+		{
+			big.NewInt(1),
+			"mainnet, recovery ID 0",
+			37,
+			0,
+		},
+		{
+			big.NewInt(1),
+			"mainnet, recovery ID 1",
+			38,
+			1,
+		},
+		{
+			big.NewInt(11155111),
+			"sepolia, recovery ID 1",
+			22310258,
+			1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := eip155Tx(t)
+
+			require.NoError(t, tx.SetSignature(testCase.chainID, testCase.recoveryID, tx.R, tx.S))
+			assert.Equal(t, big.NewInt(testCase.v), tx.V)
+
+			// Make sure the signature values survive a round trip
+			var decoded LegacyTx
+			require.NoError(t, ethrlp.Decode(ethrlp.Encode(tx), &decoded))
+
+			assert.Equal(t, testCase.chainID != nil, decoded.Protected())
+
+			chainID, err := decoded.ChainID()
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.chainID, chainID)
+
+			recoveryID, err := decoded.RecoveryID()
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.recoveryID, recoveryID)
+		})
+	}
+}
+
+func TestLegacyTx_InvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	tx := eip155Tx(t)
+
+	// Invalid recovery ID
+	assert.ErrorIs(t, tx.SetSignature(big.NewInt(1), 2, tx.R, tx.S), ErrInvalidRecoveryID)
+
+	// Missing R and S values
+	assert.ErrorIs(t, tx.SetSignature(big.NewInt(1), 0, nil, tx.S), ErrMissingSignature)
+	assert.ErrorIs(t, tx.SetSignature(big.NewInt(1), 0, tx.R, nil), ErrMissingSignature)
+
+	// Missing V value
+	tx.V = nil
+
+	_, err := tx.ChainID()
+	assert.ErrorIs(t, err, ErrInvalidV)
+
+	// V value that is neither pre-EIP-155, nor EIP-155
+	tx.V = big.NewInt(30)
+
+	_, err = tx.RecoveryID()
+	assert.ErrorIs(t, err, ErrInvalidV)
+}
+
+func TestLegacyTx_DecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		err   error
+		name  string
+		input string
+	}{
+		{
+			ethrlp.ErrExpectedList,
+			"not a list",
+			"80",
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"missing fields",
+			"c3098080",
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"invalid recipient size",
+			"cb0980808235358080808080",
+		},
+		{
+			ethrlp.ErrCanonInt,
+			"non-canonical nonce",
+			"cb8200098080808080808080",
+		},
+		{
+			ethrlp.ErrExpectedString,
+			"list value",
+			"c909808080c080808080",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var tx LegacyTx
+
+			assert.ErrorIs(t, ethrlp.Decode(hexToBytes(t, testCase.input), &tx), testCase.err)
+		})
+	}
+}
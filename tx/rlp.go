@@ -0,0 +1,209 @@
+package tx
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/sig-0/ethrlp"
+)
+
+// listFields returns the elements of the given list value,
+// making sure the list holds exactly the given number of fields
+func listFields(v ethrlp.Value, fields int) ([]ethrlp.Value, error) {
+	list, err := ethrlp.AsList(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Len() != fields {
+		return nil, fmt.Errorf(
+			"%w: expected %d list elements, got %d",
+			ethrlp.ErrInvalidLength,
+			fields,
+			list.Len(),
+		)
+	}
+
+	values, _ := list.GetValue().([]ethrlp.Value)
+
+	return values, nil
+}
+
+// valueKind returns the type of the given value,
+// which is the type of the encoded item for RawValues
+func valueKind(v ethrlp.Value) ethrlp.Type {
+	if raw, ok := v.(ethrlp.RawValue); ok {
+		return raw.Kind()
+	}
+
+	return v.GetType()
+}
+
+// decodeUint decodes the given value as an unsigned integer
+func decodeUint(v ethrlp.Value) (uint64, error) {
+	b, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.Uint64()
+}
+
+// decodeBigInt decodes the given value as a (non-negative) big integer
+func decodeBigInt(v ethrlp.Value) (*big.Int, error) {
+	b, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.BigInt()
+}
+
+// decodeBytes decodes the given value as a byte array.
+// The returned bytes do not share the memory of the value
+func decodeBytes(v ethrlp.Value) ([]byte, error) {
+	b, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{}, b.Bytes()...), nil
+}
+
+// decodeAddress decodes the given value as a 20B address
+func decodeAddress(v ethrlp.Value) (Address, error) {
+	b, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return Address{}, err
+	}
+
+	address, err := b.Address()
+
+	return Address(address), err
+}
+
+// decodeHash decodes the given value as a 32B hash
+func decodeHash(v ethrlp.Value) (Hash, error) {
+	b, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	hash, err := b.Bytes32()
+
+	return Hash(hash), err
+}
+
+// decodeYParity decodes the given value as a signature recovery ID (0 or 1)
+func decodeYParity(v ethrlp.Value) (byte, error) {
+	yParity, err := decodeUint(v)
+	if err != nil {
+		return 0, err
+	}
+
+	if yParity > 1 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidRecoveryID, yParity)
+	}
+
+	return byte(yParity), nil
+}
+
+// checkYParity makes sure the given signature recovery ID is 0 or 1
+func checkYParity(yParity byte) error {
+	if yParity > 1 {
+		return fmt.Errorf("%w: %d", ErrInvalidRecoveryID, yParity)
+	}
+
+	return nil
+}
+
+// checkSignatureValues makes sure the given signature values are set
+func checkSignatureValues(r, s *big.Int) error {
+	if r == nil {
+		return fmt.Errorf("%w: R", ErrMissingSignature)
+	}
+
+	if s == nil {
+		return fmt.Errorf("%w: S", ErrMissingSignature)
+	}
+
+	return nil
+}
+
+// decodeRecipient decodes the given value as the transaction recipient.
+// An empty value (contract creation) is decoded as nil
+func decodeRecipient(v ethrlp.Value) (*Address, error) {
+	b, err := ethrlp.AsBytes(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.Bytes()) == 0 {
+		return nil, nil
+	}
+
+	address, err := decodeAddress(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+// checkBigInt makes sure the given big integer field can be encoded
+// (nil values are encoded as zero, but negative values have no encoding)
+func checkBigInt(field string, value *big.Int) error {
+	if value != nil && value.Sign() < 0 {
+		return fieldError(field, fmt.Errorf("%w: %s", ethrlp.ErrNegativeBigInt, value))
+	}
+
+	return nil
+}
+
+// appendBigInt appends the given big integer to dst,
+// encoding a nil value as zero. The value cannot be negative (see checkBigInt)
+func appendBigInt(dst []byte, value *big.Int) []byte {
+	if value == nil {
+		return ethrlp.AppendUint64(dst, 0)
+	}
+
+	return ethrlp.AppendBigInt(dst, value)
+}
+
+// appendRecipient appends the given transaction recipient to dst,
+// encoding a nil recipient (contract creation) as an empty string
+func appendRecipient(dst []byte, to *Address) []byte {
+	if to == nil {
+		return ethrlp.AppendBytes(dst, nil)
+	}
+
+	return ethrlp.AppendBytes(dst, to[:])
+}
+
+// appendSignature appends the signature values of a typed transaction to dst
+func appendSignature(dst []byte, yParity byte, r, s *big.Int) []byte {
+	dst = ethrlp.AppendUint64(dst, uint64(yParity))
+	dst = appendBigInt(dst, r)
+
+	return appendBigInt(dst, s)
+}
+
+// headerSize returns the size of the RLP header
+// of a (non single-byte) item with the given content size
+func headerSize(size int) int {
+	if size <= 55 {
+		return 1
+	}
+
+	header := 1
+	for ; size > 0; size >>= 8 {
+		header++
+	}
+
+	return header
+}
+
+// fieldError constructs a decoding error of the given transaction field
+func fieldError(field string, err error) error {
+	return fmt.Errorf("%s: %w", field, err)
+}
@@ -0,0 +1,317 @@
+package tx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/sig-0/ethrlp"
+)
+
+const (
+	// setCodeFields is the number of fields in an encoded set code transaction
+	setCodeFields = 13
+
+	// authorizationFields is the number of fields in an encoded authorization
+	authorizationFields = 6
+
+	// authorizationMagic prefixes the signing payload of authorizations
+	authorizationMagic = 0x05
+)
+
+// Authorization is an (EIP-7702) authorization to delegate the code of the signing
+// account to the given address, encoded as the RLP list [chainID, address, nonce, yParity, r, s].
+// A zero chain ID makes the authorization valid on all chains
+type Authorization struct {
+	ChainID *big.Int // chain the authorization is bound to (zero for any chain)
+	R       *big.Int // signature R value
+	S       *big.Int // signature S value
+	Nonce   uint64   // authorizing account nonce
+	Address Address  // delegation target
+	YParity byte     // signature recovery ID
+}
+
+// AppendRLP appends the RLP encoding of the authorization to dst,
+// and returns the extended byte array.
+// The authorization needs to be valid (see Validate), otherwise AppendRLP panics
+func (a *Authorization) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = a.appendPayload(dst)
+
+		return appendSignature(dst, a.YParity, a.R, a.S)
+	})
+}
+
+// DecodeRLP decodes the given RLP value into the authorization.
+// The y parity needs to fit in a byte, but is not required to be 0 or 1,
+// since authorizations with invalid signatures do not invalidate the transaction
+func (a *Authorization) DecodeRLP(v ethrlp.Value) error {
+	values, err := listFields(v, authorizationFields)
+	if err != nil {
+		return fieldError("Authorization", err)
+	}
+
+	if a.ChainID, err = decodeBigInt(values[0]); err != nil {
+		return fieldError("Authorization.ChainID", err)
+	}
+
+	if a.Address, err = decodeAddress(values[1]); err != nil {
+		return fieldError("Authorization.Address", err)
+	}
+
+	if a.Nonce, err = decodeUint(values[2]); err != nil {
+		return fieldError("Authorization.Nonce", err)
+	}
+
+	yParity, err := decodeUint(values[3])
+	if err != nil {
+		return fieldError("Authorization.YParity", err)
+	}
+
+	if yParity > math.MaxUint8 {
+		return fieldError("Authorization.YParity", ethrlp.ErrUintOverflow)
+	}
+
+	a.YParity = byte(yParity)
+
+	if a.R, err = decodeBigInt(values[4]); err != nil {
+		return fieldError("Authorization.R", err)
+	}
+
+	if a.S, err = decodeBigInt(values[5]); err != nil {
+		return fieldError("Authorization.S", err)
+	}
+
+	return nil
+}
+
+// SigningPayload returns the payload whose Keccak-256 hash is signed
+// by the authorizing account: 0x05 || rlp([chainID, address, nonce])
+func (a *Authorization) SigningPayload() ([]byte, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ethrlp.AppendList([]byte{authorizationMagic}, a.appendPayload), nil
+}
+
+// Validate makes sure none of the big integer fields are negative
+func (a *Authorization) Validate() error {
+	return errors.Join(
+		checkBigInt("Authorization.ChainID", a.ChainID),
+		checkBigInt("Authorization.R", a.R),
+		checkBigInt("Authorization.S", a.S),
+	)
+}
+
+// SetSignature sets the signature values of the authorization
+func (a *Authorization) SetSignature(yParity byte, r, s *big.Int) error {
+	if err := checkYParity(yParity); err != nil {
+		return err
+	}
+
+	if err := checkSignatureValues(r, s); err != nil {
+		return err
+	}
+
+	a.YParity, a.R, a.S = yParity, new(big.Int).Set(r), new(big.Int).Set(s)
+
+	return nil
+}
+
+// appendPayload appends the unsigned authorization fields to dst
+func (a *Authorization) appendPayload(dst []byte) []byte {
+	dst = appendBigInt(dst, a.ChainID)
+	dst = ethrlp.AppendBytes(dst, a.Address[:])
+
+	return ethrlp.AppendUint64(dst, a.Nonce)
+}
+
+// SetCodeTx is an (EIP-7702) set code transaction, encoded as
+// 0x04 || rlp([chainID, nonce, gasTipCap, gasFeeCap, gas, to, value, data, accessList,
+// authorizations, yParity, r, s])
+type SetCodeTx struct {
+	ChainID        *big.Int        // chain the transaction is bound to
+	GasTipCap      *big.Int        // max priority fee per unit of gas
+	GasFeeCap      *big.Int        // max fee per unit of gas
+	Value          *big.Int        // transferred amount
+	R              *big.Int        // signature R value
+	S              *big.Int        // signature S value
+	Data           []byte          // call data
+	AccessList     AccessList      // pre-declared accessed accounts and storage slots
+	Authorizations []Authorization // code delegations (at least one)
+	Nonce          uint64          // sender account nonce
+	Gas            uint64          // gas limit
+	To             Address         // recipient (set code transactions cannot create contracts)
+	YParity        byte            // signature recovery ID (0 or 1)
+}
+
+// Type returns the transaction type (SetCodeTxType)
+func (tx *SetCodeTx) Type() TxType {
+	return SetCodeTxType
+}
+
+// AppendRLP appends the RLP encoding of the transaction (without its type byte)
+// to dst, and returns the extended byte array.
+// The transaction needs to be valid (see Validate), otherwise AppendRLP panics.
+// Nil big integers are encoded as zero
+func (tx *SetCodeTx) AppendRLP(dst []byte) []byte {
+	return ethrlp.AppendList(dst, func(dst []byte) []byte {
+		dst = tx.appendPayload(dst)
+
+		return appendSignature(dst, tx.YParity, tx.R, tx.S)
+	})
+}
+
+// DecodeRLP decodes the given RLP value (without the type byte) into the transaction.
+// The authorization list cannot be empty
+func (tx *SetCodeTx) DecodeRLP(v ethrlp.Value) error {
+	values, err := listFields(v, setCodeFields)
+	if err != nil {
+		return fieldError("SetCodeTx", err)
+	}
+
+	if tx.ChainID, err = decodeBigInt(values[0]); err != nil {
+		return fieldError("SetCodeTx.ChainID", err)
+	}
+
+	if tx.Nonce, err = decodeUint(values[1]); err != nil {
+		return fieldError("SetCodeTx.Nonce", err)
+	}
+
+	if tx.GasTipCap, err = decodeBigInt(values[2]); err != nil {
+		return fieldError("SetCodeTx.GasTipCap", err)
+	}
+
+	if tx.GasFeeCap, err = decodeBigInt(values[3]); err != nil {
+		return fieldError("SetCodeTx.GasFeeCap", err)
+	}
+
+	if tx.Gas, err = decodeUint(values[4]); err != nil {
+		return fieldError("SetCodeTx.Gas", err)
+	}
+
+	if tx.To, err = decodeAddress(values[5]); err != nil {
+		return fieldError("SetCodeTx.To", err)
+	}
+
+	if tx.Value, err = decodeBigInt(values[6]); err != nil {
+		return fieldError("SetCodeTx.Value", err)
+	}
+
+	if tx.Data, err = decodeBytes(values[7]); err != nil {
+		return fieldError("SetCodeTx.Data", err)
+	}
+
+	if tx.AccessList, err = decodeAccessList(values[8]); err != nil {
+		return fieldError("SetCodeTx.AccessList", err)
+	}
+
+	if tx.Authorizations, err = decodeAuthorizations(values[9]); err != nil {
+		return fieldError("SetCodeTx.Authorizations", err)
+	}
+
+	if tx.YParity, err = decodeYParity(values[10]); err != nil {
+		return fieldError("SetCodeTx.YParity", err)
+	}
+
+	if tx.R, err = decodeBigInt(values[11]); err != nil {
+		return fieldError("SetCodeTx.R", err)
+	}
+
+	if tx.S, err = decodeBigInt(values[12]); err != nil {
+		return fieldError("SetCodeTx.S", err)
+	}
+
+	return nil
+}
+
+// SigningPayload returns the payload whose Keccak-256 hash is signed:
+// 0x04 || rlp([chainID, nonce, gasTipCap, gasFeeCap, gas, to, value, data, accessList,
+// authorizations])
+func (tx *SetCodeTx) SigningPayload() ([]byte, error) {
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ethrlp.AppendList([]byte{byte(SetCodeTxType)}, tx.appendPayload), nil
+}
+
+// Validate makes sure the authorization list is not empty (ErrNoAuthorizations),
+// as such a transaction can't be decoded, and that none of the big integer fields
+// (including those of the authorizations) are negative
+func (tx *SetCodeTx) Validate() error {
+	if len(tx.Authorizations) == 0 {
+		return fieldError("SetCodeTx.Authorizations", ErrNoAuthorizations)
+	}
+
+	for index := range tx.Authorizations {
+		if err := tx.Authorizations[index].Validate(); err != nil {
+			return fmt.Errorf("SetCodeTx.Authorizations[%d]: %w", index, err)
+		}
+	}
+
+	return errors.Join(
+		checkBigInt("SetCodeTx.ChainID", tx.ChainID),
+		checkBigInt("SetCodeTx.GasTipCap", tx.GasTipCap),
+		checkBigInt("SetCodeTx.GasFeeCap", tx.GasFeeCap),
+		checkBigInt("SetCodeTx.Value", tx.Value),
+		checkBigInt("SetCodeTx.R", tx.R),
+		checkBigInt("SetCodeTx.S", tx.S),
+	)
+}
+
+// SetSignature sets the signature values of the transaction
+func (tx *SetCodeTx) SetSignature(yParity byte, r, s *big.Int) error {
+	if err := checkYParity(yParity); err != nil {
+		return err
+	}
+
+	if err := checkSignatureValues(r, s); err != nil {
+		return err
+	}
+
+	tx.YParity, tx.R, tx.S = yParity, new(big.Int).Set(r), new(big.Int).Set(s)
+
+	return nil
+}
+
+// appendPayload appends the unsigned transaction fields to dst
+func (tx *SetCodeTx) appendPayload(dst []byte) []byte {
+	dst = appendBigInt(dst, tx.ChainID)
+	dst = ethrlp.AppendUint64(dst, tx.Nonce)
+	dst = appendBigInt(dst, tx.GasTipCap)
+	dst = appendBigInt(dst, tx.GasFeeCap)
+	dst = ethrlp.AppendUint64(dst, tx.Gas)
+	dst = ethrlp.AppendBytes(dst, tx.To[:])
+	dst = appendBigInt(dst, tx.Value)
+	dst = ethrlp.AppendBytes(dst, tx.Data)
+	dst = appendAccessList(dst, tx.AccessList)
+
+	return ethrlp.AppendSlice(dst, tx.Authorizations, func(dst []byte, a Authorization) []byte {
+		return a.AppendRLP(dst)
+	})
+}
+
+// decodeAuthorizations decodes the given RLP value as a (non-empty) authorization list
+func decodeAuthorizations(v ethrlp.Value) ([]Authorization, error) {
+	authorizations, err := ethrlp.DecodeSlice(v, func(v ethrlp.Value) (Authorization, error) {
+		var a Authorization
+
+		if err := a.DecodeRLP(v); err != nil {
+			return Authorization{}, err
+		}
+
+		return a, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(authorizations) == 0 {
+		return nil, ErrNoAuthorizations
+	}
+
+	return authorizations, nil
+}
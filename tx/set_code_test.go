@@ -0,0 +1,157 @@
+package tx
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/sig-0/ethrlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setCodeTx returns a sample (signed) set code transaction, with a single authorization
+func setCodeTx() *SetCodeTx {
+	return &SetCodeTx{
+		ChainID:    big.NewInt(1),
+		Nonce:      0,
+		GasTipCap:  big.NewInt(1),
+		GasFeeCap:  big.NewInt(2),
+		Gas:        21000,
+		To:         testAddress,
+		Value:      big.NewInt(0),
+		Data:       []byte{},
+		AccessList: AccessList{},
+		Authorizations: []Authorization{
+			{
+				ChainID: big.NewInt(1),
+				Address: testAddress,
+				Nonce:   0,
+				YParity: 1,
+				R:       big.NewInt(1),
+				S:       big.NewInt(2),
+			},
+		},
+		YParity: 0,
+		R:       big.NewInt(1),
+		S:       big.NewInt(2),
+	}
+}
+
+func TestSetCodeTx_Encode(t *testing.T) {
+	t.Parallel()
+
+	tx := setCodeTx()
+
+	encoded := requireBytes(t)(EncodeEnvelope(tx))
+	assert.Equal(
+		t,
+		hexToBytes(t, `04 f83e 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0
+			db da 01 94 3535353535353535353535353535353535353535 80 01 01 02
+			80 01 02`),
+		encoded,
+	)
+
+	assert.Equal(
+		t,
+		hexToBytes(t, `04 f83b 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0
+			db da 01 94 3535353535353535353535353535353535353535 80 01 01 02`),
+		requireBytes(t)(tx.SigningPayload()),
+	)
+
+	decoded, err := DecodeEnvelope(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, tx, decoded)
+}
+
+func TestSetCodeTx_Authorization(t *testing.T) {
+	t.Parallel()
+
+	auth := Authorization{
+		ChainID: big.NewInt(0),
+		Address: testAddress,
+		Nonce:   7,
+	}
+
+	// Make sure the signing payload is prefixed with the magic byte
+	assert.Equal(
+		t,
+		hexToBytes(t, "05 d7 80 94 3535353535353535353535353535353535353535 07"),
+		requireBytes(t)(auth.SigningPayload()),
+	)
+
+	require.NoError(t, auth.SetSignature(1, big.NewInt(3), big.NewInt(4)))
+	assert.ErrorIs(t, auth.SetSignature(2, auth.R, auth.S), ErrInvalidRecoveryID)
+	assert.ErrorIs(t, auth.SetSignature(0, nil, auth.S), ErrMissingSignature)
+
+	var decoded Authorization
+	require.NoError(t, ethrlp.Decode(ethrlp.Encode(&auth), &decoded))
+
+	assert.Equal(t, auth, decoded)
+}
+
+func TestSetCodeTx_DecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		err   error
+		name  string
+		input string
+	}{
+		{
+			ErrNoAuthorizations,
+			"empty authorization list",
+			`04 e3 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0
+				c0 80 01 02`,
+		},
+		{
+			ethrlp.ErrUintOverflow,
+			"authorization y parity overflow",
+			`04 f840 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0
+				dd dc 01 94 3535353535353535353535353535353535353535 80 820100 01 02
+				80 01 02`,
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"missing authorization fields",
+			`04 f83d 01 80 01 02 825208 94 3535353535353535353535353535353535353535 80 80 c0
+				da d9 01 94 3535353535353535353535353535353535353535 80 01 01
+				80 01 02`,
+		},
+		{
+			ethrlp.ErrInvalidLength,
+			"contract creation",
+			`04 ea 01 80 01 02 825208 80 80 80 c0
+				db da 01 94 3535353535353535353535353535353535353535 80 01 01 02
+				80 01 02`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeEnvelope(hexToBytes(t, testCase.input))
+			assert.ErrorIs(t, err, testCase.err)
+		})
+	}
+
+	t.Run("empty authorization list on encode", func(t *testing.T) {
+		t.Parallel()
+
+		// A transaction that can't be decoded can't be encoded (or signed) either
+		tx := setCodeTx()
+		tx.Authorizations = nil
+
+		assert.ErrorIs(t, tx.Validate(), ErrNoAuthorizations)
+
+		_, err := EncodeEnvelope(tx)
+		assert.ErrorIs(t, err, ErrNoAuthorizations)
+
+		_, err = TxHash(tx)
+		assert.ErrorIs(t, err, ErrNoAuthorizations)
+
+		_, err = tx.SigningPayload()
+		assert.ErrorIs(t, err, ErrNoAuthorizations)
+	})
+}
@@ -0,0 +1,212 @@
+package tx
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sig-0/ethrlp"
+)
+
+const (
+	BlobSize       = 131072 // size of a blob (4096 32B field elements)
+	CommitmentSize = 48     // size of a KZG commitment
+	ProofSize      = 48     // size of a KZG proof
+
+	// CellsPerBlob is the number of cell proofs per blob (EIP-7594)
+	CellsPerBlob = 128
+)
+
+const (
+	// SidecarVersionBlobProofs is the version of sidecars with one proof per blob (EIP-4844)
+	SidecarVersionBlobProofs byte = 0
+
+	// SidecarVersionCellProofs is the version of sidecars with CellsPerBlob proofs per blob (EIP-7594)
+	SidecarVersionCellProofs byte = 1
+)
+
+const (
+	// sidecarFields is the number of sidecar fields in the network wrapper
+	// [tx, blobs, commitments, proofs]
+	sidecarFields = 3
+
+	// cellSidecarFields is the number of sidecar fields in the cell proof network wrapper
+	// [tx, version, blobs, commitments, cellProofs]
+	cellSidecarFields = 4
+)
+
+// Blob is a blob of data carried by a blob transaction
+type Blob [BlobSize]byte
+
+// Commitment is a KZG commitment to a blob
+type Commitment [CommitmentSize]byte
+
+// Proof is a KZG proof of a blob (or of a blob cell)
+type Proof [ProofSize]byte
+
+// BlobTxSidecar holds the blobs of a blob transaction, along with
+// their commitments and proofs, which are only sent over the p2p network
+type BlobTxSidecar struct {
+	Blobs       []Blob       // transaction blobs
+	Commitments []Commitment // commitments to the blobs
+	Proofs      []Proof      // blob proofs (or cell proofs, for SidecarVersionCellProofs)
+	Version     byte         // sidecar version, which determines the proof type
+}
+
+// proofsPerBlob returns the number of proofs per blob for the sidecar version
+func (s *BlobTxSidecar) proofsPerBlob() int {
+	if s.Version == SidecarVersionCellProofs {
+		return CellsPerBlob
+	}
+
+	return 1
+}
+
+// appendWrapper appends the network wrapper of the given (encoded) canonical
+// transaction and the sidecar to dst. The encoding size is computed upfront,
+// so the (large) blobs are written exactly once, without being moved
+func (s *BlobTxSidecar) appendWrapper(dst, tx []byte) []byte {
+	var (
+		blobsSize       = len(s.Blobs) * (headerSize(BlobSize) + BlobSize)
+		commitmentsSize = len(s.Commitments) * (headerSize(CommitmentSize) + CommitmentSize)
+		proofsSize      = len(s.Proofs) * (headerSize(ProofSize) + ProofSize)
+
+		size = len(tx) +
+			headerSize(blobsSize) + blobsSize +
+			headerSize(commitmentsSize) + commitmentsSize +
+			headerSize(proofsSize) + proofsSize
+	)
+
+	if s.Version != SidecarVersionBlobProofs {
+		size += len(ethrlp.EncodeUint(uint64(s.Version)))
+	}
+
+	dst = slices.Grow(dst, headerSize(size)+size)
+	dst = ethrlp.AppendListHeader(dst, size)
+	dst = append(dst, tx...)
+
+	if s.Version != SidecarVersionBlobProofs {
+		dst = ethrlp.AppendUint64(dst, uint64(s.Version))
+	}
+
+	dst = ethrlp.AppendListHeader(dst, blobsSize)
+	for index := range s.Blobs {
+		dst = ethrlp.AppendBytes(dst, s.Blobs[index][:])
+	}
+
+	dst = ethrlp.AppendListHeader(dst, commitmentsSize)
+	for index := range s.Commitments {
+		dst = ethrlp.AppendBytes(dst, s.Commitments[index][:])
+	}
+
+	dst = ethrlp.AppendListHeader(dst, proofsSize)
+	for index := range s.Proofs {
+		dst = ethrlp.AppendBytes(dst, s.Proofs[index][:])
+	}
+
+	return dst
+}
+
+// decodeWrapper decodes the given network wrapper fields
+// (following the transaction) into the sidecar.
+// The blobs are copied exactly once, and only after the size of
+// every sidecar element has been validated
+func (s *BlobTxSidecar) decodeWrapper(values []ethrlp.Value) error {
+	switch len(values) {
+	case sidecarFields:
+		s.Version = SidecarVersionBlobProofs
+	case cellSidecarFields:
+		version, err := decodeUint(values[0])
+		if err != nil {
+			return fieldError("Version", err)
+		}
+
+		if version != uint64(SidecarVersionCellProofs) {
+			return fmt.Errorf("%w: unsupported version %d", ErrInvalidSidecar, version)
+		}
+
+		s.Version, values = SidecarVersionCellProofs, values[1:]
+	default:
+		return fmt.Errorf(
+			"%w: expected %d or %d list elements, got %d",
+			ethrlp.ErrInvalidLength,
+			sidecarFields+1,
+			cellSidecarFields+1,
+			len(values)+1,
+		)
+	}
+
+	blobs, err := fixedSizeElements(values[0], BlobSize)
+	if err != nil {
+		return fieldError("Blobs", err)
+	}
+
+	commitments, err := fixedSizeElements(values[1], CommitmentSize)
+	if err != nil {
+		return fieldError("Commitments", err)
+	}
+
+	proofs, err := fixedSizeElements(values[2], ProofSize)
+	if err != nil {
+		return fieldError("Proofs", err)
+	}
+
+	if len(commitments) != len(blobs) || len(proofs) != len(blobs)*s.proofsPerBlob() {
+		return fmt.Errorf(
+			"%w: %d blobs, %d commitments, %d proofs",
+			ErrInvalidSidecar,
+			len(blobs),
+			len(commitments),
+			len(proofs),
+		)
+	}
+
+	s.Blobs = make([]Blob, len(blobs))
+	for index, blob := range blobs {
+		copy(s.Blobs[index][:], blob)
+	}
+
+	s.Commitments = make([]Commitment, len(commitments))
+	for index, commitment := range commitments {
+		copy(s.Commitments[index][:], commitment)
+	}
+
+	s.Proofs = make([]Proof, len(proofs))
+	for index, proof := range proofs {
+		copy(s.Proofs[index][:], proof)
+	}
+
+	return nil
+}
+
+// fixedSizeElements returns the content of the elements of the given list value,
+// making sure each one is a byte string of the given size.
+// The returned elements share the memory of the value
+func fixedSizeElements(v ethrlp.Value, size int) ([][]byte, error) {
+	list, err := ethrlp.AsList(v)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([][]byte, 0, list.Len())
+
+	for index, value := range list.All() {
+		b, err := ethrlp.AsBytes(value)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", index, err)
+		}
+
+		if len(b.Bytes()) != size {
+			return nil, fmt.Errorf(
+				"element %d: %w: expected %dB, got %dB",
+				index,
+				ethrlp.ErrInvalidLength,
+				size,
+				len(b.Bytes()),
+			)
+		}
+
+		elements = append(elements, b.Bytes())
+	}
+
+	return elements, nil
+}
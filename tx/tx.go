@@ -0,0 +1,26 @@
+// Package tx implements the RLP encoding and decoding of Ethereum transactions,
+// on top of the (reflection-free) ethrlp package.
+//
+// Signing and signature recovery are out of scope: the package produces the payloads
+// that need to be hashed (ethrlp.HashOf) and signed, and keeps the resulting signature values
+package tx
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidV          = errors.New("invalid signature V value")
+	ErrInvalidRecoveryID = errors.New("invalid signature recovery ID")
+	ErrMissingSignature  = errors.New("missing signature value")
+	ErrInvalidTxType     = errors.New("invalid transaction type")
+	ErrUnsupportedTxType = errors.New("unsupported transaction type")
+	ErrInvalidSidecar    = errors.New("invalid blob sidecar")
+	ErrNoAuthorizations  = errors.New("empty authorization list")
+)
+
+// Address is a 20B Ethereum account address
+type Address [20]byte
+
+// Hash is a 32B Keccak-256 hash (or storage key)
+type Hash [32]byte
@@ -1,19 +1,36 @@
 package ethrlp
 
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/big"
+)
+
+var (
+	ErrInvalidBool     = errors.New("invalid boolean value")
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
 type Type byte
 
 func (t Type) String() string {
 	switch t {
 	case Bytes:
 		return "Bytes"
-	default:
+	case List:
 		return "List"
+	case Raw:
+		return "Raw"
+	default:
+		return "Unknown"
 	}
 }
 
 var (
 	Bytes Type = 0x1
 	List  Type = 0x2
+	Raw   Type = 0x3
 )
 
 // Value is a decoded data value
@@ -29,6 +46,45 @@ type BytesValue struct {
 	value []byte
 }
 
+// NewBytes creates a new byte value
+func NewBytes(value []byte) BytesValue {
+	return BytesValue{value: value}
+}
+
+// NewString creates a new byte value from the given string
+func NewString(value string) BytesValue {
+	return BytesValue{value: []byte(value)}
+}
+
+// NewUint creates a new byte value holding the minimal
+// big-endian representation of the given unsigned integer
+func NewUint(value uint64) BytesValue {
+	size := intSize(value)
+
+	return BytesValue{value: appendBigEndian(make([]byte, 0, size), value, size)}
+}
+
+// NewBigInt creates a new byte value holding the minimal
+// big-endian representation of the given big integer.
+// NewBigInt panics if the input is negative or nil
+func NewBigInt(value *big.Int) BytesValue {
+	if err := checkBigInt(value); err != nil {
+		panic(err)
+	}
+
+	return BytesValue{value: value.Bytes()}
+}
+
+// NewBool creates a new byte value holding the given boolean
+// (0x01 for true, an empty value for false)
+func NewBool(value bool) BytesValue {
+	if value {
+		return BytesValue{value: []byte{0x01}}
+	}
+
+	return BytesValue{value: []byte{}}
+}
+
 func (b BytesValue) GetType() Type {
 	return Bytes
 }
@@ -37,10 +93,82 @@ func (b BytesValue) GetValue() any {
 	return b.value
 }
 
+// Bytes returns the raw byte value
+func (b BytesValue) Bytes() []byte {
+	return b.value
+}
+
+// String returns the byte value as a string
+func (b BytesValue) String() string {
+	return string(b.value)
+}
+
+// Uint64 decodes the byte value as an unsigned integer.
+// The value needs to be at most 8B long, with no leading zero bytes
+func (b BytesValue) Uint64() (uint64, error) {
+	return decodeUint64(b.value)
+}
+
+// BigInt decodes the byte value as a (non-negative) big integer.
+// The value cannot have leading zero bytes
+func (b BytesValue) BigInt() (*big.Int, error) {
+	return decodeBigInt(b.value)
+}
+
+// Bool decodes the byte value as a boolean,
+// encoded as either 0x01 (true) or an empty byte value (false)
+func (b BytesValue) Bool() (bool, error) {
+	switch {
+	case len(b.value) == 0:
+		return false, nil
+	case len(b.value) == 1 && b.value[0] == 0x01:
+		return true, nil
+	default:
+		return false, ErrInvalidBool
+	}
+}
+
+// Bytes32 returns the byte value as a 32B array (for example, a hash).
+// The value needs to be exactly 32B long
+func (b BytesValue) Bytes32() ([32]byte, error) {
+	var result [32]byte
+
+	if len(b.value) != len(result) {
+		return result, constructLengthError(len(result), len(b.value))
+	}
+
+	copy(result[:], b.value)
+
+	return result, nil
+}
+
+// Address returns the byte value as a 20B array (an Ethereum address).
+// The value needs to be exactly 20B long
+func (b BytesValue) Address() ([20]byte, error) {
+	var result [20]byte
+
+	if len(b.value) != len(result) {
+		return result, constructLengthError(len(result), len(b.value))
+	}
+
+	copy(result[:], b.value)
+
+	return result, nil
+}
+
 type ListValue struct {
 	values []Value
 }
 
+// NewList creates a new list value with the given elements
+func NewList(values ...Value) ListValue {
+	if values == nil {
+		values = []Value{}
+	}
+
+	return ListValue{values: values}
+}
+
 func (a ListValue) GetType() Type {
 	return List
 }
@@ -48,3 +176,115 @@ func (a ListValue) GetType() Type {
 func (a ListValue) GetValue() any {
 	return a.values
 }
+
+// Len returns the number of list elements
+func (a ListValue) Len() int {
+	return len(a.values)
+}
+
+// At returns the list element at the given index
+func (a ListValue) At(index int) (Value, error) {
+	if index < 0 || index >= len(a.values) {
+		return nil, fmt.Errorf("%w: index %d, length %d", ErrIndexOutOfRange, index, len(a.values))
+	}
+
+	return a.values[index], nil
+}
+
+// All returns an iterator over the list elements, and their indexes
+func (a ListValue) All() iter.Seq2[int, Value] {
+	return func(yield func(int, Value) bool) {
+		for index, value := range a.values {
+			if !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// AsBytes returns the given value as a BytesValue,
+// if it is of the Bytes type. A RawValue holding a byte string is decoded
+func AsBytes(v Value) (BytesValue, error) {
+	if r, ok := v.(RawValue); ok {
+		return r.asBytes()
+	}
+
+	b, ok := v.(BytesValue)
+	if !ok {
+		return BytesValue{}, ErrExpectedString
+	}
+
+	return b, nil
+}
+
+// AsList returns the given value as a ListValue,
+// if it is of the List type. A RawValue holding a list is split
+// into its elements, which are kept as (undecoded) RawValues
+func AsList(v Value) (ListValue, error) {
+	if r, ok := v.(RawValue); ok {
+		return r.asList()
+	}
+
+	l, ok := v.(ListValue)
+	if !ok {
+		return ListValue{}, ErrExpectedList
+	}
+
+	return l, nil
+}
+
+// Get returns the value at the given path of list indexes, starting from v.
+// For example, Get(v, 0, 3, 1) returns the second element
+// of the fourth element of the first element of v.
+// The returned error names the path segment that failed
+func Get(v Value, path ...int) (Value, error) {
+	for segment, index := range path {
+		list, err := AsList(v)
+		if err != nil {
+			return nil, pathError(path, segment, err)
+		}
+
+		if v, err = list.At(index); err != nil {
+			return nil, pathError(path, segment, err)
+		}
+	}
+
+	return v, nil
+}
+
+// GetBytes returns the value at the given path of list indexes,
+// starting from v, and makes sure it is a BytesValue
+func GetBytes(v Value, path ...int) (BytesValue, error) {
+	value, err := Get(v, path...)
+	if err != nil {
+		return BytesValue{}, err
+	}
+
+	b, err := AsBytes(value)
+	if err != nil {
+		return BytesValue{}, fmt.Errorf("path %v: %w", path, err)
+	}
+
+	return b, nil
+}
+
+// GetList returns the value at the given path of list indexes,
+// starting from v, and makes sure it is a ListValue
+func GetList(v Value, path ...int) (ListValue, error) {
+	value, err := Get(v, path...)
+	if err != nil {
+		return ListValue{}, err
+	}
+
+	l, err := AsList(value)
+	if err != nil {
+		return ListValue{}, fmt.Errorf("path %v: %w", path, err)
+	}
+
+	return l, nil
+}
+
+// pathError constructs a path lookup error for the given failing path segment
+func pathError(path []int, segment int, err error) error {
+	return fmt.Errorf("path %v, segment %d (index %d): %w", path, segment, path[segment], err)
+}
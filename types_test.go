@@ -0,0 +1,312 @@
+package ethrlp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytesValue_Uint64(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		expectedErr   error
+		name          string
+		input         []byte
+		expectedValue uint64
+	}{
+		{
+			nil,
+			"zero",
+			[]byte{},
+			0,
+		},
+		{
+			nil,
+			"single byte",
+			[]byte{0x7f},
+			0x7f,
+		},
+		{
+			nil,
+			"max uint64",
+			hexToBytes(t, "ffffffffffffffff"),
+			0xffffffffffffffff,
+		},
+		{
+			ErrCanonInt,
+			"leading zeros",
+			hexToBytes(t, "0001"),
+			0,
+		},
+		{
+			ErrUintOverflow,
+			"larger than 8B",
+			hexToBytes(t, "010000000000000000"),
+			0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := BytesValue{value: testCase.input}.Uint64()
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedValue, value)
+		})
+	}
+}
+
+func TestBytesValue_BigInt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid big int", func(t *testing.T) {
+		t.Parallel()
+
+		expectedNumber, ok := big.NewInt(0).SetString(
+			"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+			10,
+		)
+		require.True(t, ok)
+
+		value, err := DecodeBytes(hexToBytes(t, "a1010000000000000000000000000000000000000000000000000000000000000000"))
+		require.NoError(t, err)
+
+		bytesValue, err := AsBytes(value)
+		require.NoError(t, err)
+
+		number, err := bytesValue.BigInt()
+		require.NoError(t, err)
+
+		assert.Zero(t, expectedNumber.Cmp(number))
+	})
+
+	t.Run("leading zeros", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := BytesValue{value: hexToBytes(t, "0001")}.BigInt()
+		assert.ErrorIs(t, err, ErrCanonInt)
+	})
+}
+
+func TestBytesValue_Bool(t *testing.T) {
+	t.Parallel()
+
+	value, err := BytesValue{value: []byte{0x01}}.Bool()
+	require.NoError(t, err)
+
+	assert.True(t, value)
+
+	value, err = BytesValue{value: []byte{}}.Bool()
+	require.NoError(t, err)
+
+	assert.False(t, value)
+
+	_, err = BytesValue{value: []byte{0x02}}.Bool()
+	assert.ErrorIs(t, err, ErrInvalidBool)
+
+	_, err = BytesValue{value: []byte{0x00}}.Bool()
+	assert.ErrorIs(t, err, ErrInvalidBool)
+}
+
+func TestBytesValue_FixedSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("address", func(t *testing.T) {
+		t.Parallel()
+
+		raw := hexToBytes(t, "3535353535353535353535353535353535353535")
+
+		address, err := BytesValue{value: raw}.Address()
+		require.NoError(t, err)
+
+		assert.Equal(t, raw, address[:])
+
+		_, err = BytesValue{value: raw[1:]}.Address()
+		assert.ErrorIs(t, err, ErrInvalidLength)
+	})
+
+	t.Run("bytes32", func(t *testing.T) {
+		t.Parallel()
+
+		raw := hexToBytes(t, "28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276")
+
+		hash, err := BytesValue{value: raw}.Bytes32()
+		require.NoError(t, err)
+
+		assert.Equal(t, raw, hash[:])
+
+		_, err = BytesValue{value: append(raw, 0x00)}.Bytes32()
+		assert.ErrorIs(t, err, ErrInvalidLength)
+	})
+}
+
+func TestBytesValue_String(t *testing.T) {
+	t.Parallel()
+
+	value, err := DecodeBytes(hexToBytes(t, "83646f67"))
+	require.NoError(t, err)
+
+	bytesValue, err := AsBytes(value)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dog", bytesValue.String())
+	assert.Equal(t, []byte("dog"), bytesValue.Bytes())
+}
+
+func TestAsBytes(t *testing.T) {
+	t.Parallel()
+
+	_, err := AsBytes(ListValue{})
+	assert.ErrorIs(t, err, ErrExpectedString)
+
+	_, err = AsBytes(nil)
+	assert.ErrorIs(t, err, ErrExpectedString)
+}
+
+func TestListValue_Navigation(t *testing.T) {
+	t.Parallel()
+
+	// [ [], [[]], [ [], [[]] ] ]
+	value, err := DecodeBytes(hexToBytes(t, "c7c0c1c0c3c0c1c0"))
+	require.NoError(t, err)
+
+	list, err := AsList(value)
+	require.NoError(t, err)
+
+	t.Run("length", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, 3, list.Len())
+	})
+
+	t.Run("index access", func(t *testing.T) {
+		t.Parallel()
+
+		element, err := list.At(2)
+		require.NoError(t, err)
+
+		assert.Equal(t, List, element.GetType())
+
+		_, err = list.At(3)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+
+		_, err = list.At(-1)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	})
+
+	t.Run("iteration", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			indexes = make([]int, 0, list.Len())
+			lengths = make([]int, 0, list.Len())
+		)
+
+		for index, element := range list.All() {
+			elementList, err := AsList(element)
+			require.NoError(t, err)
+
+			indexes = append(indexes, index)
+			lengths = append(lengths, elementList.Len())
+		}
+
+		assert.Equal(t, []int{0, 1, 2}, indexes)
+		assert.Equal(t, []int{0, 1, 2}, lengths)
+	})
+
+	t.Run("early break", func(t *testing.T) {
+		t.Parallel()
+
+		iterations := 0
+
+		for range list.All() {
+			iterations++
+
+			break
+		}
+
+		assert.Equal(t, 1, iterations)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	// [ "cat", [ "dog", [ "cow", "pig" ] ] ]
+	value, err := DecodeBytes(EncodeArray([][]byte{
+		EncodeString("cat"),
+		EncodeArray([][]byte{
+			EncodeString("dog"),
+			EncodeArray([][]byte{
+				EncodeString("cow"),
+				EncodeString("pig"),
+			}),
+		}),
+	}))
+	require.NoError(t, err)
+
+	t.Run("empty path", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := Get(value)
+		require.NoError(t, err)
+
+		assert.Equal(t, value, result)
+	})
+
+	t.Run("nested bytes", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := GetBytes(value, 1, 1, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, "pig", result.String())
+	})
+
+	t.Run("nested list", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := GetList(value, 1, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, result.Len())
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Get(value, 1, 2, 0)
+		require.ErrorIs(t, err, ErrIndexOutOfRange)
+
+		assert.ErrorContains(t, err, "segment 1 (index 2)")
+	})
+
+	t.Run("wrong node type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Get(value, 0, 1)
+		require.ErrorIs(t, err, ErrExpectedList)
+
+		assert.ErrorContains(t, err, "segment 1 (index 1)")
+	})
+
+	t.Run("wrong leaf type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := GetBytes(value, 1, 1)
+		assert.ErrorIs(t, err, ErrExpectedString)
+
+		_, err = GetList(value, 0)
+		assert.ErrorIs(t, err, ErrExpectedList)
+	})
+}